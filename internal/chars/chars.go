@@ -121,3 +121,38 @@ func IsBlank(s string) bool {
 }
 
 const DefaultGroupName = "174b9e831dec431181e31ede822bb3b5"
+
+// KebabCase converts the given Go identifier into a lowercase, dash separated
+// string suitable for use as a long-form flag or command name.
+//
+// Runs of capital letters are treated as a single word, so "HTTPServer"
+// becomes "http-server" and "MaxLines" becomes "max-lines".
+func KebabCase(name string) string {
+	out := make([]byte, 0, len(name)+4)
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+
+		if IsUpper(c) {
+			if i > 0 && name[i-1] != '_' && (!IsUpper(name[i-1]) || (i+1 < len(name) && IsLower(name[i+1]))) {
+				out = append(out, CharDash)
+			}
+
+			out = append(out, c+('a'-'A'))
+		} else if c == '_' {
+			out = append(out, CharDash)
+		} else {
+			out = append(out, c)
+		}
+	}
+
+	return string(out)
+}
+
+func IsUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+func IsLower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
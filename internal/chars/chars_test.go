@@ -164,3 +164,21 @@ func TestIsBlank(t *testing.T) {
 		t.Errorf("expected false but got true")
 	}
 }
+
+func TestKebabCase(t *testing.T) {
+	tests := map[string]string{
+		"Verbose":    "verbose",
+		"MaxLines":   "max-lines",
+		"HTTPServer": "http-server",
+		"InputFile":  "input-file",
+		"Max_Lines":  "max-lines",
+		"ID":         "id",
+		"already":    "already",
+	}
+
+	for input, expect := range tests {
+		if actual := chars.KebabCase(input); actual != expect {
+			t.Errorf("expected %s to become %s but got %s", input, expect, actual)
+		}
+	}
+}
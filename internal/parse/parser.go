@@ -49,12 +49,28 @@ func NewParser(e emit.Emitter) Parser {
 }
 
 type Parser struct {
-	sb      strings.Builder
-	state   state
-	emitter emit.Emitter
+	sb       strings.Builder
+	state    state
+	emitter  emit.Emitter
+	position int
 }
 
+// Next returns the next element parsed from the CLI args.
+//
+// Every element other than the end marker is parsed from exactly one CLI arg,
+// and is given the index of that arg as its position.
 func (p *Parser) Next() Element {
+	out := p.next()
+
+	if out.Type != ElementTypeEnd {
+		p.position++
+		out.Position = p.position
+	}
+
+	return out
+}
+
+func (p *Parser) next() Element {
 	next := p.emitter.Next()
 
 	if p.state == statePass && next.Kind != emit.EventKindEnd {
@@ -277,6 +293,12 @@ func (p *Parser) handleEnd() Element {
 type Element struct {
 	Type ElementType
 	Data []string
+
+	// Position is the index of the CLI arg this element was parsed from, where
+	// the program name is at index 0.
+	//
+	// The end marker element has a position of 0.
+	Position int
 }
 
 func (e Element) String() string {
@@ -278,3 +278,18 @@ func TestElement_String(t *testing.T) {
 		}
 	}
 }
+
+func TestParser_Next10(t *testing.T) {
+	input := []string{"com", "--foo", "-abc", "bar", "--", "-x"}
+	parser := parse.NewParser(emit.NewEmitter(input))
+
+	for i := 1; i < len(input); i++ {
+		if next := parser.Next(); next.Position != i {
+			t.Errorf("expected element %s to have position %d but was %d", next.String(), i, next.Position)
+		}
+	}
+
+	if next := parser.Next(); next.Type != parse.ElementTypeEnd || next.Position != 0 {
+		t.Error("expected end element with position 0")
+	}
+}
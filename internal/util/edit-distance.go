@@ -0,0 +1,51 @@
+package util
+
+// EditDistance returns the Damerau-Levenshtein distance between the given
+// strings, which is the number of single character insertions, deletions,
+// substitutions, and transpositions of adjacent characters needed to turn one
+// string into the other.
+//
+// This is the optimal string alignment variant of the distance, in which no
+// substring is edited more than once.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	if len(ra) == 0 {
+		return len(rb)
+	}
+
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	// Only the last two rows of the distance matrix are needed to compute the
+	// next row.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
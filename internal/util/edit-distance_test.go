@@ -0,0 +1,29 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/Foxcapades/Argonaut/internal/util"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"status", "status", 0},
+		{"stauts", "status", 1},
+		{"verbos", "verbose", 1},
+		{"kitten", "sitting", 3},
+		{"ca", "abc", 3},
+	}
+
+	for _, test := range tests {
+		if actual := util.EditDistance(test.a, test.b); actual != test.expected {
+			t.Errorf("expected distance between %q and %q to be %d but was %d", test.a, test.b, test.expected, actual)
+		}
+	}
+}
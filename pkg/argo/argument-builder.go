@@ -2,10 +2,13 @@ package argo
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 
+	"github.com/Foxcapades/Argonaut/internal/chars"
 	"github.com/Foxcapades/Argonaut/internal/unmarshal"
 	"github.com/Foxcapades/Argonaut/internal/xarg"
+	"github.com/Foxcapades/Argonaut/internal/xreflect"
 )
 
 // An ArgumentBuilder instance is used to construct a CLI argument that may be
@@ -122,6 +125,79 @@ type ArgumentBuilder interface {
 
 	getDefault() any
 
+	// WithEnvVar sets the name of an environment variable that will be used as
+	// the source of this argument's value when the argument is not provided on
+	// the command line.
+	//
+	// Values read from the environment are handled exactly as if they had been
+	// passed on the command line; they are passed through the argument's
+	// ValueUnmarshaler and any pre- or post-parse validators.
+	//
+	// An environment variable value takes priority over a value set using
+	// WithDefault.  If the environment variable is not set, the default value
+	// (if any) will be used.
+	//
+	// Example:
+	//     cli.Argument().
+	//         WithBinding(&timeout).
+	//         WithEnvVar("APP_TIMEOUT").
+	//         WithDefault(30 * time.Second)
+	WithEnvVar(name string) ArgumentBuilder
+
+	getEnvVar() string
+
+	// WithCompleter sets a function that will be used to provide completion
+	// candidates for this argument's value when the program is invoked in
+	// dynamic completion mode.
+	//
+	// The given function will be called with the partial value currently being
+	// completed, and should return the candidate values to offer.
+	//
+	// Example:
+	//     cli.Argument().
+	//         WithBinding(&branch).
+	//         WithCompleter(func(prefix string) []string {
+	//             return listGitBranches(prefix)
+	//         })
+	WithCompleter(fn ArgumentCompleter) ArgumentBuilder
+
+	// Variadic marks the Argument being built as variadic, meaning it will
+	// consume all remaining positional values in the CLI call rather than just
+	// one.
+	//
+	// Only the last positional argument on a command may be variadic, and flag
+	// arguments may not be variadic.  If the last positional argument on a
+	// command is bound to a slice, it will be made variadic automatically.
+	//
+	// Each value is individually passed through the argument's validators and
+	// unmarshaler, and for slice bindings, appended to the bound slice.
+	//
+	// Example:
+	//     cli.Argument().
+	//         WithName("files").
+	//         WithBinding(&files).
+	//         Variadic()
+	Variadic() ArgumentBuilder
+
+	isVariadic() bool
+
+	// WithMinCount sets the minimum number of values that must be passed to the
+	// variadic Argument being built.
+	//
+	// A minimum count greater than zero marks the argument as required.
+	//
+	// This method may only be used on variadic arguments.
+	WithMinCount(min int) ArgumentBuilder
+
+	// WithMaxCount sets the maximum number of values that may be passed to the
+	// variadic Argument being built.
+	//
+	// Positional values beyond the max count will be treated as unmapped inputs.
+	// A max count of zero, the default, means there is no limit.
+	//
+	// This method may only be used on variadic arguments.
+	WithMaxCount(max int) ArgumentBuilder
+
 	// WithUnmarshaler allows providing a custom ValueUnmarshaler instance that
 	// will be used to unmarshal string values into the binding type.
 	//
@@ -162,6 +238,29 @@ type ArgumentBuilder interface {
 	// Validators will be executed in the order they are appended.
 	WithValidator(validatorFn any) ArgumentBuilder
 
+	// Hidden hides the positional Argument being built from rendered help text,
+	// documentation, JSON schemas, and shell completions.
+	//
+	// Hidden arguments still accept values in a CLI call.  Flag arguments may not
+	// be hidden; hide the flag instead.
+	Hidden() ArgumentBuilder
+
+	// Deprecated marks the positional Argument being built as deprecated.
+	//
+	// When a value is passed to a deprecated argument in a CLI call, a warning
+	// including the given message is appended to the command's warnings.  Flag
+	// arguments may not be deprecated; deprecate the flag instead.
+	//
+	// Example:
+	//     cli.Argument().
+	//         WithName("format").
+	//         Deprecated("use --format instead")
+	Deprecated(message string) ArgumentBuilder
+
+	isHidden() bool
+
+	isDeprecated() bool
+
 	// Build attempts to build an Argument instance out of the configuration given
 	// to this ArgumentBuilder instance.
 	//
@@ -187,6 +286,14 @@ type argumentBuilder struct {
 	def  any
 	bind any
 
+	envVar string
+
+	completer ArgumentCompleter
+
+	variadic bool
+	minCount int
+	maxCount int
+
 	rootDef  reflect.Value
 	rootBind reflect.Value
 
@@ -194,6 +301,10 @@ type argumentBuilder struct {
 
 	validators []any
 
+	hidden      bool
+	deprecated  bool
+	deprecation string
+
 	errors []error
 }
 
@@ -236,6 +347,39 @@ func (a argumentBuilder) getDefault() any {
 	return a.def
 }
 
+func (a *argumentBuilder) WithEnvVar(name string) ArgumentBuilder {
+	a.envVar = name
+	return a
+}
+
+func (a argumentBuilder) getEnvVar() string {
+	return a.envVar
+}
+
+func (a *argumentBuilder) WithCompleter(fn ArgumentCompleter) ArgumentBuilder {
+	a.completer = fn
+	return a
+}
+
+func (a *argumentBuilder) Variadic() ArgumentBuilder {
+	a.variadic = true
+	return a
+}
+
+func (a argumentBuilder) isVariadic() bool {
+	return a.variadic
+}
+
+func (a *argumentBuilder) WithMinCount(min int) ArgumentBuilder {
+	a.minCount = min
+	return a
+}
+
+func (a *argumentBuilder) WithMaxCount(max int) ArgumentBuilder {
+	a.maxCount = max
+	return a
+}
+
 func (a *argumentBuilder) WithUnmarshaler(fn ValueUnmarshaler) ArgumentBuilder {
 	a.marsh = fn
 	return a
@@ -246,6 +390,25 @@ func (a *argumentBuilder) WithValidator(fn any) ArgumentBuilder {
 	return a
 }
 
+func (a *argumentBuilder) Hidden() ArgumentBuilder {
+	a.hidden = true
+	return a
+}
+
+func (a argumentBuilder) isHidden() bool {
+	return a.hidden
+}
+
+func (a *argumentBuilder) Deprecated(message string) ArgumentBuilder {
+	a.deprecated = true
+	a.deprecation = message
+	return a
+}
+
+func (a argumentBuilder) isDeprecated() bool {
+	return a.deprecated
+}
+
 func (a *argumentBuilder) Build(warnings *WarningContext) (Argument, error) {
 	errs := newMultiError()
 
@@ -273,6 +436,20 @@ func (a *argumentBuilder) Build(warnings *WarningContext) (Argument, error) {
 		}
 	}
 
+	if len(a.envVar) > 0 {
+		if err := validateEnvVarName(a.envVar); err != nil {
+			errs.AppendError(err)
+		}
+	}
+
+	if a.minCount < 0 || a.maxCount < 0 {
+		errs.AppendError(errors.New("argument value counts must not be negative"))
+	} else if !a.variadic && (a.minCount > 0 || a.maxCount > 0) {
+		errs.AppendError(errors.New("value counts set on an argument that is not variadic"))
+	} else if a.maxCount > 0 && a.minCount > a.maxCount {
+		errs.AppendError(errors.New("argument min count must not be greater than its max count"))
+	}
+
 	var pre, post []any
 	var err error
 	pre, post, err = xarg.SiftValidators(a.validators, &a.rootBind, a.bindKind)
@@ -288,15 +465,66 @@ func (a *argumentBuilder) Build(warnings *WarningContext) (Argument, error) {
 		warnings:            warnings,
 		name:                a.name,
 		desc:                a.desc,
-		required:            a.required,
+		required:            a.required || a.minCount > 0,
 		bindingKind:         a.bindKind,
 		defaultKind:         a.defaultKind,
 		bindVal:             a.bind,
 		defVal:              a.def,
+		envVar:              a.envVar,
+		completer:           a.completer,
+		variadic:            a.variadic,
+		minCount:            a.minCount,
+		maxCount:            a.maxCount,
 		rootBind:            a.rootBind,
 		rootDef:             a.rootDef,
 		unmarshal:           a.marsh,
 		preParseValidators:  pre,
 		postParseValidators: post,
+		hidden:              a.hidden,
+		deprecated:          a.deprecated,
+		deprecation:         a.deprecation,
 	}, nil
 }
+
+func validateEnvVarName(name string) error {
+	if chars.IsBlank(name) {
+		return errors.New("environment variable names must not be blank")
+	}
+
+	for i := 0; i < len(name); i++ {
+		if name[i] == chars.CharEquals || chars.IsWhitespace(name[i]) {
+			return errors.New("environment variable names must not contain whitespace or equals characters")
+		}
+	}
+
+	return nil
+}
+
+// prepareVariadicArguments marks the last of the given positional argument
+// builders as variadic if it is bound to a slice, and appends an error for
+// every other argument builder that was marked as variadic.
+func prepareVariadicArguments(builders []ArgumentBuilder, errs MultiError) {
+	last := len(builders) - 1
+
+	for i, builder := range builders {
+		if i < last {
+			if builder.isVariadic() {
+				errs.AppendError(fmt.Errorf("argument %d is variadic but is not the last positional argument", i+1))
+			}
+		} else if bindsToSlice(builder.getBinding()) {
+			builder.Variadic()
+		}
+	}
+}
+
+// bindsToSlice tests whether the given binding value is a pointer to a slice
+// that is not a byte slice.
+func bindsToSlice(binding any) bool {
+	if binding == nil {
+		return false
+	}
+
+	bt := reflect.TypeOf(binding)
+
+	return bt.Kind() == reflect.Pointer && xreflect.IsSlice(bt.Elem()) && !xreflect.IsByteSlice(bt.Elem())
+}
@@ -215,3 +215,19 @@ func TestArgumentBuilder_Build14(t *testing.T) {
 		t.Error("expected error not to be nil but it was")
 	}
 }
+
+func TestArgumentBuilder_countsWithoutVariadic(t *testing.T) {
+	_, err := cli.Argument().WithMinCount(1).Build(nil)
+
+	if err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
+
+func TestArgumentBuilder_minGreaterThanMax(t *testing.T) {
+	_, err := cli.Argument().Variadic().WithMinCount(3).WithMaxCount(2).Build(nil)
+
+	if err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
@@ -8,6 +8,11 @@ import (
 	"github.com/Foxcapades/Argonaut/internal/xreflect"
 )
 
+// ArgumentCompleter defines a function type that may be used to provide
+// completion candidates for an argument's value given the partial value
+// currently being completed.
+type ArgumentCompleter = func(prefix string) []string
+
 // Argument represents a positional or flag argument that may be attached
 // directly to a Command or CommandLeaf, or may be attached to a Flag.
 type Argument interface {
@@ -39,6 +44,28 @@ type Argument interface {
 	// nil.
 	DefaultType() reflect.Type
 
+	// EnvVar returns the name of the environment variable that will be used as
+	// a fallback source for this Argument's value.
+	//
+	// If this Argument does not have an environment variable set, this method
+	// will return an empty string.
+	EnvVar() string
+
+	// HasEnvVar indicates whether an environment variable fallback has been set
+	// on this Argument.
+	HasEnvVar() bool
+
+	// HasCompleter indicates whether a completion function has been set on this
+	// Argument.
+	HasCompleter() bool
+
+	// Completions returns the completion candidates for this Argument's value
+	// given the partial value currently being completed.
+	//
+	// If this Argument does not have a completion function set, this method will
+	// return nil.
+	Completions(prefix string) []string
+
 	// Description returns the description attached to this Argument.
 	//
 	// If no description was attached to this Argument when it was built, this
@@ -71,9 +98,41 @@ type Argument interface {
 	// returned.
 	IsRequired() bool
 
+	// IsVariadic indicates whether this Argument consumes all remaining
+	// positional values in a CLI call.
+	IsVariadic() bool
+
+	// MinCount returns the minimum number of values that must be passed to this
+	// Argument if it is variadic.
+	MinCount() int
+
+	// MaxCount returns the maximum number of values that may be passed to this
+	// Argument if it is variadic.
+	//
+	// A value of zero means there is no limit.
+	MaxCount() int
+
+	// RawValues returns all the raw text values that were assigned to this
+	// Argument in the CLI call, in the order they were assigned.
+	//
+	// For arguments that are not variadic, and not attached to repeated flags,
+	// this will contain at most one value.
+	RawValues() []string
+
 	// HasBinding indicates whether this Argument has a value binding.
 	HasBinding() bool
 
+	// IsHidden indicates whether this Argument is hidden from rendered help text,
+	// documentation, and shell completions.
+	IsHidden() bool
+
+	// IsDeprecated indicates whether this Argument has been marked as deprecated.
+	IsDeprecated() bool
+
+	// DeprecationMessage returns the message included in the warning recorded
+	// when a value is passed to this Argument, if it is deprecated.
+	DeprecationMessage() string
+
 	AppendWarning(warning string)
 
 	// BindingType returns the reflect.Type value for the configured binding.
@@ -81,7 +140,9 @@ type Argument interface {
 	// If this argument has no binding, this method will return nil.
 	BindingType() reflect.Type
 	setValue(rawValue string) error
+	acceptsValue() bool
 	setToDefault() error
+	setToEnv(rt *Runtime) (bool, error)
 }
 
 type argument struct {
@@ -90,6 +151,7 @@ type argument struct {
 	name string
 	desc string
 	raw  string
+	raws []string
 
 	required bool
 	isUsed   bool
@@ -99,6 +161,13 @@ type argument struct {
 
 	bindVal any
 	defVal  any
+	envVar  string
+
+	completer ArgumentCompleter
+
+	variadic bool
+	minCount int
+	maxCount int
 
 	rootBind reflect.Value
 	rootDef  reflect.Value
@@ -107,6 +176,10 @@ type argument struct {
 
 	preParseValidators  []any
 	postParseValidators []any
+
+	hidden      bool
+	deprecated  bool
+	deprecation string
 }
 
 func (a argument) Name() string {
@@ -153,6 +226,26 @@ func (a argument) DefaultType() reflect.Type {
 	}
 }
 
+func (a argument) EnvVar() string {
+	return a.envVar
+}
+
+func (a argument) HasEnvVar() bool {
+	return len(a.envVar) > 0
+}
+
+func (a argument) HasCompleter() bool {
+	return a.completer != nil
+}
+
+func (a argument) Completions(prefix string) []string {
+	if a.completer == nil {
+		return nil
+	}
+
+	return a.completer(prefix)
+}
+
 func (a argument) WasHit() bool {
 	return a.isUsed
 }
@@ -165,6 +258,44 @@ func (a argument) IsRequired() bool {
 	return a.required
 }
 
+func (a argument) IsVariadic() bool {
+	return a.variadic
+}
+
+func (a argument) MinCount() int {
+	return a.minCount
+}
+
+func (a argument) MaxCount() int {
+	return a.maxCount
+}
+
+func (a argument) RawValues() []string {
+	return a.raws
+}
+
+func (a argument) IsHidden() bool {
+	return a.hidden
+}
+
+func (a argument) IsDeprecated() bool {
+	return a.deprecated
+}
+
+func (a argument) DeprecationMessage() string {
+	return a.deprecation
+}
+
+// acceptsValue tests whether this argument may be assigned another positional
+// value.
+func (a argument) acceptsValue() bool {
+	if !a.isUsed {
+		return true
+	}
+
+	return a.variadic && (a.maxCount == 0 || len(a.raws) < a.maxCount)
+}
+
 func (a argument) AppendWarning(warning string) {
 	a.warnings.appendWarning(warning)
 }
@@ -243,9 +374,28 @@ func (a *argument) setToDefault() error {
 	return nil
 }
 
+// setToEnv attempts to set the value of this argument from the configured
+// environment variable.
+//
+// Returns a flag indicating whether the environment variable was present and
+// used, and any error encountered while setting the value.
+func (a *argument) setToEnv(rt *Runtime) (bool, error) {
+	if !a.HasEnvVar() {
+		return false, nil
+	}
+
+	raw, ok := rt.lookupEnv(a.envVar)
+	if !ok {
+		return false, nil
+	}
+
+	return true, a.setValue(raw)
+}
+
 func (a *argument) setValue(rawString string) error {
 	a.isUsed = true
 	a.raw = rawString
+	a.raws = append(a.raws, rawString)
 
 	for _, fn := range a.preParseValidators {
 		if err := a.callPreArgFunc(fn, rawString); err != nil {
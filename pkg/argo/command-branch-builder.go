@@ -2,7 +2,6 @@ package argo
 
 import (
 	"errors"
-	"os"
 
 	"github.com/Foxcapades/Argonaut/internal/chars"
 	"github.com/Foxcapades/Argonaut/internal/util"
@@ -81,6 +80,39 @@ type CommandBranchBuilder interface {
 
 	WithCallback(cb CommandBranchCallback) CommandBranchBuilder
 
+	// WithPreRun appends a hook that will be run by Execute before the handler of
+	// any CommandLeaf beneath the CommandBranch being built.
+	//
+	// Pre-run hooks are called in the order they were added, after the pre-run
+	// hooks of any parent nodes.  If a pre-run hook returns an error, the
+	// remaining pre-run hooks and the leaf handler will not be called.
+	WithPreRun(hook CommandPreRunHook) CommandBranchBuilder
+
+	// WithPostRun appends a hook that will be run by Execute after the handler of
+	// any CommandLeaf beneath the CommandBranch being built.
+	//
+	// Post-run hooks are called in the order they were added, before the
+	// post-run hooks of any parent nodes.  Post-run hooks are always called, even
+	// when a pre-run hook or the leaf handler fails, and receive that failure.
+	WithPostRun(hook CommandPostRunHook) CommandBranchBuilder
+
+	// WithMiddleware appends the given middleware to the chain that wraps the
+	// handler of every CommandLeaf beneath the CommandBranch being built.
+	//
+	// The first middleware added is the outermost.  The middleware of a node
+	// wraps that node's pre-run and post-run hooks, as well as the hooks and
+	// middleware of any child nodes.
+	//
+	// Example:
+	//     cli.Branch("deploy").
+	//         WithMiddleware(func(next argo.CommandLeafHandler) argo.CommandLeafHandler {
+	//             return func(ctx context.Context, leaf argo.CommandLeaf) error {
+	//                 log.Println("running", leaf.Name())
+	//                 return next(ctx, leaf)
+	//             }
+	//         })
+	WithMiddleware(middleware ...CommandLeafMiddleware) CommandBranchBuilder
+
 	// OnIncomplete sets the incomplete command handler.
 	//
 	// The incomplete command handler is called when a command tree is called, but
@@ -90,6 +122,32 @@ type CommandBranchBuilder interface {
 	// furthest command node reached and exit with code 1.
 	OnIncomplete(handler OnIncompleteHandler) CommandBranchBuilder
 
+	// Hidden hides the CommandBranch being built from rendered help text,
+	// documentation, and shell completions.
+	//
+	// Hidden branches may still be used in a CLI call.
+	Hidden() CommandBranchBuilder
+
+	// Deprecated marks the CommandBranch being built as deprecated.
+	//
+	// When a deprecated branch is used in a CLI call, a warning including the
+	// given message is appended to the command tree's warnings.
+	Deprecated(message string) CommandBranchBuilder
+
+	// ReplacedBy names a sibling subcommand that will be used in place of the
+	// deprecated CommandBranch being built when it appears in a CLI call.
+	//
+	// The CommandBranch being built must also be marked as deprecated.
+	//
+	// Example:
+	//     cli.Tree().
+	//         WithBranch(cli.Branch("services")).
+	//         WithBranch(cli.Branch("svc").
+	//             Hidden().
+	//             Deprecated("use services instead").
+	//             ReplacedBy("services"))
+	ReplacedBy(name string) CommandBranchBuilder
+
 	Build(warnings *WarningContext) (CommandBranch, error)
 }
 
@@ -110,6 +168,12 @@ type commandBranchBuilder struct {
 	aliases      []string
 	parentNode   CommandParent
 	callback     CommandBranchCallback
+	hooks        commandHooks
+
+	hidden      bool
+	deprecated  bool
+	deprecation string
+	replacement string
 
 	onIncompleteHandler OnIncompleteHandler
 }
@@ -171,11 +235,42 @@ func (c *commandBranchBuilder) WithFlagGroup(flagGroup FlagGroupBuilder) Command
 	return c
 }
 
+func (c *commandBranchBuilder) WithPreRun(hook CommandPreRunHook) CommandBranchBuilder {
+	c.hooks.preRun = append(c.hooks.preRun, hook)
+	return c
+}
+
+func (c *commandBranchBuilder) WithPostRun(hook CommandPostRunHook) CommandBranchBuilder {
+	c.hooks.postRun = append(c.hooks.postRun, hook)
+	return c
+}
+
+func (c *commandBranchBuilder) WithMiddleware(middleware ...CommandLeafMiddleware) CommandBranchBuilder {
+	c.hooks.middleware = append(c.hooks.middleware, middleware...)
+	return c
+}
+
 func (c *commandBranchBuilder) OnIncomplete(handler OnIncompleteHandler) CommandBranchBuilder {
 	c.onIncompleteHandler = handler
 	return c
 }
 
+func (c *commandBranchBuilder) Hidden() CommandBranchBuilder {
+	c.hidden = true
+	return c
+}
+
+func (c *commandBranchBuilder) Deprecated(message string) CommandBranchBuilder {
+	c.deprecated = true
+	c.deprecation = message
+	return c
+}
+
+func (c *commandBranchBuilder) ReplacedBy(name string) CommandBranchBuilder {
+	c.replacement = name
+	return c
+}
+
 func (c *commandBranchBuilder) Build(ctx *WarningContext) (CommandBranch, error) {
 	errs := newMultiError()
 
@@ -191,6 +286,10 @@ func (c *commandBranchBuilder) Build(ctx *WarningContext) (CommandBranch, error)
 		}
 	}
 
+	if len(c.replacement) > 0 && !c.deprecated {
+		errs.AppendError(errors.New("replacement set on a command branch that is not deprecated"))
+	}
+
 	// Ensure a parent is set
 	if c.parentNode == nil {
 		panic("illegal state: attempted to build a command branch with no parent set")
@@ -253,10 +352,12 @@ func (c *commandBranchBuilder) Build(ctx *WarningContext) (CommandBranch, error)
 			}
 
 			flag := NewFlagBuilder().
+				setIsHelpFlag().
 				WithDescription("Prints this help text.").
 				WithCallback(func(f Flag) {
-					util.Must(comBranchRenderer{}.RenderHelp(out, os.Stdout))
-					os.Exit(0)
+					rt := nodeRuntime(out)
+					util.Must(comBranchRenderer{}.RenderHelp(out, rt.stdout()))
+					rt.exit(0)
 				})
 
 			if !hasLongH {
@@ -309,6 +410,11 @@ func (c *commandBranchBuilder) Build(ctx *WarningContext) (CommandBranch, error)
 	out.parent = c.parentNode
 	out.aliases = c.aliases
 	out.callback = c.callback
+	out.hooks = c.hooks
+	out.hidden = c.hidden
+	out.deprecated = c.deprecated
+	out.deprecation = c.deprecation
+	out.replacement = c.replacement
 	out.onIncompleteHandler = util.IfElse(c.onIncompleteHandler != nil, c.onIncompleteHandler, nil)
 
 	return out, nil
@@ -36,7 +36,12 @@ type commandBranch struct {
 	flagGroups    []FlagGroup
 	commandGroups []CommandGroup
 	callback      CommandBranchCallback
+	hooks         commandHooks
 	warnings      *WarningContext
+	hidden        bool
+	deprecated    bool
+	deprecation   string
+	replacement   string
 
 	onIncompleteHandler OnIncompleteHandler
 }
@@ -44,13 +49,12 @@ type commandBranch struct {
 // Find Child //////////////////////////////////////////////////////////////////
 
 func (c commandBranch) FindChild(name string) CommandChild {
-	for _, group := range c.commandGroups {
-		if com := group.FindChild(name); com != nil {
-			return com
-		}
-	}
+	child, _ := c.resolveChild(name)
+	return child
+}
 
-	return nil
+func (c commandBranch) resolveChild(name string) (CommandChild, error) {
+	return lookupChild(c.commandGroups, name, nodeAllowsAbbreviations(&c))
 }
 
 // On Hit //////////////////////////////////////////////////////////////////////
@@ -65,6 +69,10 @@ func (c commandBranch) hasCallback() bool {
 	return c.callback != nil
 }
 
+func (c commandBranch) wrapHandler(next CommandLeafHandler) CommandLeafHandler {
+	return c.hooks.wrap(next)
+}
+
 // Parent //////////////////////////////////////////////////////////////////////
 
 func (c commandBranch) Parent() CommandNode {
@@ -111,6 +119,28 @@ func (c commandBranch) HasAliases() bool {
 	return len(c.aliases) > 0
 }
 
+// Deprecation /////////////////////////////////////////////////////////////////
+
+func (c commandBranch) IsHidden() bool {
+	return c.hidden
+}
+
+func (c commandBranch) IsDeprecated() bool {
+	return c.deprecated
+}
+
+func (c commandBranch) DeprecationMessage() string {
+	return c.deprecation
+}
+
+func (c commandBranch) HasReplacement() bool {
+	return len(c.replacement) > 0
+}
+
+func (c commandBranch) Replacement() string {
+	return c.replacement
+}
+
 // Matches /////////////////////////////////////////////////////////////////////
 
 func (c commandBranch) Matches(name string) bool {
@@ -146,13 +176,17 @@ func (c commandBranch) FindShortFlag(b byte) Flag {
 }
 
 func (c commandBranch) FindLongFlag(name string) Flag {
-	for _, group := range c.FlagGroups() {
-		if flag := group.FindLongFlag(name); flag != nil {
-			return flag
-		}
-	}
+	flag, _ := c.resolveLongFlag(name)
+	return flag
+}
+
+func (c commandBranch) resolveLongFlag(name string) (Flag, error) {
+	return lookupLongFlag(nodeFlagGroups(&c), name, nodeAllowsAbbreviations(&c))
+}
 
-	return c.parent.FindLongFlag(name)
+func (c commandBranch) findExactLongFlag(name string) Flag {
+	flag, _ := lookupLongFlag(nodeFlagGroups(&c), name, false)
+	return flag
 }
 
 func (c commandBranch) Warnings() []string {
@@ -163,6 +197,10 @@ func (c commandBranch) AppendWarning(warning string) {
 	c.warnings.appendWarning(warning)
 }
 
+func (c commandBranch) appendWarningError(err error) {
+	c.warnings.appendWarningError(err)
+}
+
 func (c commandBranch) onIncomplete(node CommandParent) {
 	if c.onIncompleteHandler != nil {
 		c.onIncompleteHandler(node)
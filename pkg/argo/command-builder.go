@@ -1,8 +1,8 @@
 package argo
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/Foxcapades/Argonaut/internal/chars"
 	"github.com/Foxcapades/Argonaut/internal/util"
@@ -64,6 +64,124 @@ type CommandBuilder interface {
 	// after CLI parsing has completed successfully.
 	WithCallback(cb CommandCallback) CommandBuilder
 
+	// WithHandler sets a handler function that will be executed by Execute after
+	// CLI parsing has completed successfully and all callbacks have been
+	// executed.
+	//
+	// Unlike callbacks, handlers receive a context that is cancelled when the
+	// process receives SIGINT or SIGTERM, and may return an error that will be
+	// returned to the caller of Execute.
+	WithHandler(handler CommandHandler) CommandBuilder
+
+	// WithConfigFile sets the path to a configuration file that flag values will
+	// be loaded from.
+	//
+	// Configuration file values are keyed by flag long-form name, and are passed
+	// through each flag argument's unmarshaler and validators exactly like values
+	// passed on the command line.  The order of precedence for flag values is:
+	// CLI input, then environment variables, then the configuration file, then
+	// defaults.
+	//
+	// Files ending in ".json" are parsed as JSON, files ending in ".yaml" or
+	// ".yml" are parsed as a subset of YAML, and all other files are parsed as
+	// INI.
+	//
+	// If the file does not exist, it will be ignored.
+	//
+	// Example Config File:
+	//     timeout = 30s
+	//     verbose = true
+	//     labels = [a=1, b=2]
+	WithConfigFile(path string) CommandBuilder
+
+	// WithConfigFlag adds a flag with the given long-form name that may be used
+	// to provide the path to a configuration file on the CLI.
+	//
+	// A path given by the flag takes priority over the path set with
+	// WithConfigFile.  Unlike the default configuration file path, if the path
+	// given by the flag does not exist, an error will be returned.
+	//
+	// See WithConfigFile for details on configuration files.
+	WithConfigFlag(name string) CommandBuilder
+
+	// WithRuntime sets the Runtime that the built Command will use for writing
+	// help text, exiting, determining the program name, and looking up
+	// environment variables.
+	//
+	// If no Runtime is set, the default process environment will be used.
+	WithRuntime(rt *Runtime) CommandBuilder
+
+	// WithAbbreviations enables abbreviated long flags on the CLI.
+	//
+	// When enabled, a long flag that does not exactly match any flag available
+	// to the command being called may be any unique prefix of one of those flags'
+	// long forms, for example `--verb` for `--verbose`.  If the prefix matches
+	// more than one flag, an AmbiguousPrefixError listing the candidates will be
+	// returned.
+	//
+	// Negated flag forms, and the flag names referenced by other flags, for
+	// example via RequiresFlag or ReplacedBy, are never abbreviated and must use
+	// the full long form.
+	WithAbbreviations() CommandBuilder
+
+	// WithResponseFiles enables response file expansion for the Command being
+	// built.
+	//
+	// When enabled, any CLI argument of the form `@path` is replaced with the
+	// arguments read from the file at that path before the CLI call is parsed.
+	// Arguments in a response file are separated by whitespace, may be quoted
+	// with single or double quotes, and may have characters escaped with a
+	// backslash.  Lines starting with '#' are ignored.
+	//
+	// Response files may reference other response files, with relative paths
+	// being resolved against the directory of the referencing file.  The path
+	// `@-` reads arguments from the Runtime's stdin.  Arguments following an
+	// end-of-arguments boundary, "--", are not expanded.
+	//
+	// Example:
+	//     $ cat args.rsp
+	//     --define 'name=My App'
+	//     @common.rsp
+	//     $ my-app @args.rsp build
+	WithResponseFiles() CommandBuilder
+
+	// WithInterspersedDisabled disables the use of flags after positional
+	// arguments for the Command being built.
+	//
+	// When disabled, the first positional argument in the CLI call ends flag
+	// parsing, and it and every input following it are treated as positional
+	// arguments, even if they look like flags.  This is useful for wrapper
+	// commands that pass flags through to another program.
+	//
+	// This mode is also enabled when the POSIXLY_CORRECT environment variable
+	// is set.
+	//
+	// Example:
+	//     $ my-app --verbose exec ls -la
+	//     # "exec", "ls", and "-la" are all positional arguments.
+	WithInterspersedDisabled() CommandBuilder
+
+	// WithSuggestionThreshold sets the maximum edit distance between an
+	// unrecognized long flag and a known one for the known flag to be suggested
+	// as an alternative.
+	//
+	// Edit distance is the number of single character insertions, deletions,
+	// substitutions, or transpositions needed to turn one name into the other.
+	// Defaults to DefaultSuggestionThreshold.  A threshold less than 1 disables
+	// suggestions.
+	WithSuggestionThreshold(distance int) CommandBuilder
+
+	// WithStrictParsing enables strict parsing for the Command being built.
+	//
+	// In strict mode, unrecognized flags, and positional arguments that are not
+	// accepted by any of the Command's arguments, cause parsing to fail with a
+	// ParseError instead of being recorded as warnings and unmapped inputs.
+	// Unrecognized long flags are reported with suggestions of similar flags.
+	//
+	// If an unmapped label has been set with WithUnmappedLabel, extra positional
+	// arguments are still collected as unmapped inputs.
+	WithStrictParsing() CommandBuilder
+
 	Build(ctx *WarningContext) (Command, error)
 
 	// Parse reads the given arguments and attempts to populate the built Command
@@ -74,11 +192,29 @@ type CommandBuilder interface {
 	// building the Command or parsing the input arguments, this method will
 	// panic.
 	MustParse(args []string) Command
+
+	// Execute builds the Command, parses the given arguments, then calls the
+	// Command's handler, if it has one.
+	//
+	// The handler is passed a context derived from the given context that will
+	// be cancelled when the process receives SIGINT or SIGTERM.
+	//
+	// If building fails, the build error is returned.  If parsing fails, the
+	// parse error is returned wrapped in an ExitError with the code
+	// ExitCodeUsage.  Otherwise, the error returned by the handler is returned.
+	// Pass the returned error to ExitCode to determine the exit code the process
+	// should exit with.
+	//
+	// If help text is printed and the Runtime's exit function returns, an
+	// ExitError with the code ExitCodeSuccess is returned, so only errors with a
+	// non-zero exit code should be reported.
+	Execute(ctx context.Context, args []string) error
 }
 
 func NewCommandBuilder() CommandBuilder {
 	return &commandBuilder{
-		flagGroups: []FlagGroupBuilder{NewFlagGroupBuilder(chars.DefaultGroupName)},
+		flagGroups:  []FlagGroupBuilder{NewFlagGroupBuilder(chars.DefaultGroupName)},
+		suggestions: DefaultSuggestionThreshold,
 	}
 }
 
@@ -89,6 +225,15 @@ type commandBuilder struct {
 	arguments   []ArgumentBuilder
 	disableHelp bool
 	callback    CommandCallback
+	handler     CommandHandler
+	config      configLoader
+	runtime     *Runtime
+
+	abbreviations bool
+	responseFiles bool
+	noIntersperse bool
+	suggestions   int
+	strict        bool
 }
 
 func (b *commandBuilder) WithDescription(desc string) CommandBuilder {
@@ -126,12 +271,57 @@ func (b *commandBuilder) WithCallback(cb CommandCallback) CommandBuilder {
 	return b
 }
 
+func (b *commandBuilder) WithHandler(handler CommandHandler) CommandBuilder {
+	b.handler = handler
+	return b
+}
+
+func (b *commandBuilder) WithConfigFile(path string) CommandBuilder {
+	b.config.path = path
+	return b
+}
+
+func (b *commandBuilder) WithConfigFlag(name string) CommandBuilder {
+	b.config.flag = name
+	return b
+}
+
+func (b *commandBuilder) WithRuntime(rt *Runtime) CommandBuilder {
+	b.runtime = rt
+	return b
+}
+
+func (b *commandBuilder) WithAbbreviations() CommandBuilder {
+	b.abbreviations = true
+	return b
+}
+
+func (b *commandBuilder) WithResponseFiles() CommandBuilder {
+	b.responseFiles = true
+	return b
+}
+
+func (b *commandBuilder) WithInterspersedDisabled() CommandBuilder {
+	b.noIntersperse = true
+	return b
+}
+
+func (b *commandBuilder) WithSuggestionThreshold(distance int) CommandBuilder {
+	b.suggestions = distance
+	return b
+}
+
+func (b *commandBuilder) WithStrictParsing() CommandBuilder {
+	b.strict = true
+	return b
+}
+
 func (b commandBuilder) Parse(args []string) (Command, error) {
 	ctx := new(WarningContext)
 	if cmd, err := b.Build(ctx); err != nil {
 		return nil, err
 	} else {
-		if err = newCommandInterpreter(args, cmd).Run(); err != nil {
+		if err = ParseCommand(cmd, args); err != nil {
 			return nil, err
 		}
 
@@ -143,11 +333,30 @@ func (b commandBuilder) MustParse(args []string) Command {
 	return util.MustReturn(b.Parse(args))
 }
 
+func (b commandBuilder) Execute(ctx context.Context, args []string) error {
+	cmd, err := b.Build(new(WarningContext))
+	if err != nil {
+		return err
+	}
+
+	return ExecuteCommand(ctx, cmd, args)
+}
+
 func (b commandBuilder) Build(ctx *WarningContext) (Command, error) {
 	errs := newMultiError()
 	com := new(command)
 
 	com.warnings = ctx
+	com.runtime = b.runtime
+	com.abbreviations = b.abbreviations
+	com.responseFiles = b.responseFiles
+	com.noIntersperse = b.noIntersperse
+	com.suggestions = b.suggestions
+	com.strict = b.strict
+
+	if len(b.config.flag) > 0 {
+		b.flagGroups[0].WithFlag(b.config.makeFlag())
+	}
 
 	if !b.disableHelp {
 		group := b.flagGroups[0]
@@ -192,6 +401,10 @@ func (b commandBuilder) Build(ctx *WarningContext) (Command, error) {
 		}
 	}
 
+	validateFlagReferences(com, com.flagGroups, errs)
+
+	prepareVariadicArguments(b.arguments, errs)
+
 	forceRequiredUntil := 0
 	for i, builder := range b.arguments {
 		if builder.isRequired() {
@@ -221,6 +434,8 @@ func (b commandBuilder) Build(ctx *WarningContext) (Command, error) {
 	com.description = b.description
 	com.unmappedLabel = b.unmapLabel
 	com.callback = b.callback
+	com.handler = b.handler
+	com.config = b.config
 
 	return com, nil
 }
@@ -229,8 +444,9 @@ func makeCommandHelpFlag(short, long bool, com Command) FlagBuilder {
 	out := NewFlagBuilder().
 		setIsHelpFlag().
 		WithCallback(func(flag Flag) {
-			util.Must(comRenderer{}.RenderHelp(com, os.Stdout))
-			os.Exit(0)
+			rt := com.getRuntime()
+			util.Must(comRenderer{}.RenderHelp(com, rt.stdout()))
+			rt.exit(0)
 		}).
 		WithDescription("Prints this help text.")
 
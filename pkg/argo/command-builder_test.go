@@ -138,3 +138,36 @@ func TestCommandBuilder_OptionalArgumentBeforeRequiredArgument(t *testing.T) {
 		}
 	}
 }
+
+func TestCommandBuilder_unknownFlagReference(t *testing.T) {
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("cert").RequiresFlag("key")).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not be nil, but it was")
+	}
+}
+
+func TestCommandBuilder_abbreviatedFlagReference(t *testing.T) {
+	_, err := cli.Command().
+		WithAbbreviations().
+		WithFlag(cli.LongFlag("verbose")).
+		WithFlag(cli.LongFlag("debug").RequiresFlag("verb")).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not be nil, but it was")
+	}
+}
+
+func TestCommandBuilder_variadicNotLast(t *testing.T) {
+	_, err := cli.Command().
+		WithArgument(cli.Argument().Variadic()).
+		WithArgument(cli.Argument()).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not be nil, but it was")
+	}
+}
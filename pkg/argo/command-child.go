@@ -15,4 +15,24 @@ type CommandChild interface {
 	// Matches tests whether the branch name or any of its aliases match the given
 	// string.
 	Matches(name string) bool
+
+	// IsHidden indicates whether this CommandChild is hidden from rendered help
+	// text, documentation, and shell completions.
+	IsHidden() bool
+
+	// IsDeprecated indicates whether this CommandChild has been marked as
+	// deprecated.
+	IsDeprecated() bool
+
+	// DeprecationMessage returns the message included in the warning recorded
+	// when this CommandChild is used, if it is deprecated.
+	DeprecationMessage() string
+
+	// HasReplacement indicates whether this CommandChild names a sibling
+	// subcommand that is used in its place.
+	HasReplacement() bool
+
+	// Replacement returns the name of the sibling subcommand that is used in
+	// place of this CommandChild.
+	Replacement() string
 }
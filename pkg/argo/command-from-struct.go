@@ -0,0 +1,408 @@
+package argo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+	"github.com/Foxcapades/Argonaut/internal/unmarshal"
+)
+
+// CommandFromStruct constructs a new CommandBuilder from the `argo` struct tags
+// on the fields of the struct pointed to by the given value.
+//
+// Each tagged field is bound to the flag or argument it declares, producing the
+// same builder graph as would be written by hand with WithBinding calls.
+// Untagged fields are ignored, with the exception of embedded structs, whose
+// fields are treated as if they were declared on the outer struct.
+//
+// Supported tag keys:
+//     short=c       Short-form flag character.
+//     long=name     Long-form flag name.
+//     name=name     Argument, group, or subcommand name.
+//     desc=text     Help-text description.
+//     env=NAME      Environment variable fallback (see WithEnvVar).
+//     default=raw   Default value, parsed as if it were passed on the CLI.
+//     alias=name    Subcommand alias, may be repeated.
+//     required      Marks the flag or positional argument as required.
+//     arg-optional  Makes the argument of a non-boolean flag optional.
+//     arg           Declares the field as a positional argument.
+//     group         Declares a nested struct field as a flag group.
+//     leaf          Declares a struct field as a command leaf (trees only).
+//     branch        Declares a struct field as a command branch (trees only).
+//
+// A tagged field that declares none of "short", "long", "arg", "group", "leaf",
+// or "branch" becomes a flag whose long-form name is derived from the field
+// name, unless the field is a struct that cannot be bound as a value, in which
+// case it becomes a flag group.  A field tagged with "-" is skipped.
+//
+// Commas and backslashes in tag values may be escaped with a backslash.
+//
+// Example:
+//     type Config struct {
+//         Verbose   bool          `argo:"short=v,long=verbose,desc=Enable verbose logging."`
+//         MaxLines  int           `argo:"short=m,required"`
+//         Timeout   time.Duration `argo:"short=t,env=APP_TIMEOUT,default=30s"`
+//         InputFile string        `argo:"arg,name=file,required"`
+//     }
+//
+//     var config Config
+//
+//     builder, err := argo.CommandFromStruct(&config)
+//     if err != nil {
+//         panic(err)
+//     }
+//
+//     builder.MustParse(os.Args)
+func CommandFromStruct(config any) (CommandBuilder, error) {
+	root, err := structRootValue(config)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewCommandBuilder()
+	errs := newMultiError()
+
+	walkStruct(root, root.Type().Name(), structSink{
+		flag:  func(f FlagBuilder) { builder.WithFlag(f) },
+		group: func(g FlagGroupBuilder) { builder.WithFlagGroup(g) },
+		arg:   func(a ArgumentBuilder) { builder.WithArgument(a) },
+	}, errs)
+
+	if len(errs.Errors()) > 0 {
+		return nil, errs
+	}
+
+	return builder, nil
+}
+
+// CommandTreeFromStruct constructs a new CommandTreeBuilder from the `argo`
+// struct tags on the fields of the struct pointed to by the given value.
+//
+// Tagged struct fields marked with "leaf" become CommandLeafBuilders and those
+// marked with "branch" become CommandBranchBuilders, each built from the tags on
+// their own fields.  Flags declared directly on the root struct are attached to
+// the tree root.
+//
+// See CommandFromStruct for the supported tag keys.
+//
+// Example:
+//     type Build struct {
+//         Output string `argo:"short=o,long=output"`
+//         Target string `argo:"arg,required"`
+//     }
+//
+//     type Config struct {
+//         Verbose bool  `argo:"short=v"`
+//         Build   Build `argo:"leaf,desc=Builds the target."`
+//     }
+func CommandTreeFromStruct(config any) (CommandTreeBuilder, error) {
+	root, err := structRootValue(config)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewCommandTreeBuilder()
+	errs := newMultiError()
+
+	walkStruct(root, root.Type().Name(), structSink{
+		flag:   func(f FlagBuilder) { builder.WithFlag(f) },
+		group:  func(g FlagGroupBuilder) { builder.WithFlagGroup(g) },
+		leaf:   func(l CommandLeafBuilder) { builder.WithLeaf(l) },
+		branch: func(b CommandBranchBuilder) { builder.WithBranch(b) },
+	}, errs)
+
+	if len(errs.Errors()) > 0 {
+		return nil, errs
+	}
+
+	return builder, nil
+}
+
+// structSink holds the attachment points available at a given level of a
+// struct walk.  A nil function indicates that the component type is not
+// permitted at that level.
+type structSink struct {
+	flag   func(FlagBuilder)
+	group  func(FlagGroupBuilder)
+	arg    func(ArgumentBuilder)
+	leaf   func(CommandLeafBuilder)
+	branch func(CommandBranchBuilder)
+}
+
+func structRootValue(config any) (reflect.Value, error) {
+	value := reflect.ValueOf(config)
+
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return value, errors.New("config must be a non-nil pointer to a struct")
+	}
+
+	return value.Elem(), nil
+}
+
+func walkStruct(value reflect.Value, path string, sink structSink, errs MultiError) {
+	vType := value.Type()
+
+	for i := 0; i < vType.NumField(); i++ {
+		field := vType.Field(i)
+		fieldPath := path + "." + field.Name
+
+		raw, tagged := field.Tag.Lookup(StructTagName)
+
+		if !tagged {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && field.IsExported() {
+				walkStruct(value.Field(i), path, sink, errs)
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			errs.AppendError(newStructFieldError(fieldPath, errors.New("tagged fields must be exported")))
+			continue
+		}
+
+		tag, err := parseStructTag(raw)
+		if err != nil {
+			errs.AppendError(newStructFieldError(fieldPath, err))
+			continue
+		}
+
+		if tag.kind == structTagKindSkip {
+			continue
+		}
+
+		if tag.kind == structTagKindUnknown {
+			if isStructGroupType(field.Type) {
+				tag.kind = structTagKindFlagGroup
+			} else {
+				tag.kind = structTagKindFlag
+				tag.long = chars.KebabCase(field.Name)
+			}
+		}
+
+		if err := tag.validate(); err != nil {
+			errs.AppendError(newStructFieldError(fieldPath, err))
+			continue
+		}
+
+		fieldValue := value.Field(i)
+
+		switch tag.kind {
+
+		case structTagKindFlag:
+			if sink.flag == nil {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("flags are not permitted here")))
+				continue
+			}
+
+			sink.flag(structFlag(fieldValue, &tag))
+
+		case structTagKindArgument:
+			if sink.arg == nil {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("positional arguments are not permitted here")))
+				continue
+			}
+
+			arg := structArgument(fieldValue, &tag)
+
+			if len(tag.name) == 0 {
+				arg.WithName(chars.KebabCase(field.Name))
+			}
+
+			if tag.required {
+				arg.Require()
+			}
+
+			sink.arg(arg)
+
+		case structTagKindFlagGroup:
+			if sink.group == nil {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("flag groups are not permitted here")))
+				continue
+			}
+
+			if field.Type.Kind() != reflect.Struct {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("flag group fields must be structs")))
+				continue
+			}
+
+			group := NewFlagGroupBuilder(structNodeName(&tag, field.Name)).
+				WithDescription(tag.desc)
+
+			walkStruct(fieldValue, fieldPath, structSink{
+				flag: func(f FlagBuilder) { group.WithFlag(f) },
+			}, errs)
+
+			sink.group(group)
+
+		case structTagKindLeaf:
+			if sink.leaf == nil {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("command leaves are only permitted in command trees")))
+				continue
+			}
+
+			if field.Type.Kind() != reflect.Struct {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("command leaf fields must be structs")))
+				continue
+			}
+
+			leaf := NewCommandLeafBuilder(structNodeName(&tag, chars.KebabCase(field.Name))).
+				WithDescription(tag.desc).
+				WithAliases(tag.aliases...)
+
+			walkStruct(fieldValue, fieldPath, structSink{
+				flag:  func(f FlagBuilder) { leaf.WithFlag(f) },
+				group: func(g FlagGroupBuilder) { leaf.WithFlagGroup(g) },
+				arg:   func(a ArgumentBuilder) { leaf.WithArgument(a) },
+			}, errs)
+
+			sink.leaf(leaf)
+
+		case structTagKindBranch:
+			if sink.branch == nil {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("command branches are only permitted in command trees")))
+				continue
+			}
+
+			if field.Type.Kind() != reflect.Struct {
+				errs.AppendError(newStructFieldError(fieldPath, errors.New("command branch fields must be structs")))
+				continue
+			}
+
+			branch := NewCommandBranchBuilder(structNodeName(&tag, chars.KebabCase(field.Name))).
+				WithDescription(tag.desc).
+				WithAliases(tag.aliases...)
+
+			walkStruct(fieldValue, fieldPath, structSink{
+				flag:   func(f FlagBuilder) { branch.WithFlag(f) },
+				group:  func(g FlagGroupBuilder) { branch.WithFlagGroup(g) },
+				leaf:   func(l CommandLeafBuilder) { branch.WithLeaf(l) },
+				branch: func(b CommandBranchBuilder) { branch.WithBranch(b) },
+			}, errs)
+
+			sink.branch(branch)
+		}
+	}
+}
+
+func structFlag(field reflect.Value, tag *structTag) FlagBuilder {
+	arg := structArgument(field, tag)
+
+	if !tag.argOptional && field.Kind() != reflect.Bool {
+		arg.Require()
+	}
+
+	flag := NewFlagBuilder().
+		WithDescription(tag.desc).
+		WithArgument(arg)
+
+	if tag.short != 0 {
+		flag.WithShortForm(tag.short)
+	}
+
+	if len(tag.long) > 0 {
+		flag.WithLongForm(tag.long)
+	}
+
+	if tag.required {
+		flag.Require()
+	}
+
+	return flag
+}
+
+func structArgument(field reflect.Value, tag *structTag) ArgumentBuilder {
+	arg := NewArgumentBuilder().WithBinding(field.Addr().Interface())
+
+	if len(tag.name) > 0 {
+		arg.WithName(tag.name)
+	}
+
+	if tag.kind == structTagKindArgument && len(tag.desc) > 0 {
+		arg.WithDescription(tag.desc)
+	}
+
+	if len(tag.env) > 0 {
+		arg.WithEnvVar(tag.env)
+	}
+
+	if tag.hasDefault {
+		arg.WithDefault(tag.def)
+	}
+
+	return arg
+}
+
+func structNodeName(tag *structTag, fallback string) string {
+	if len(tag.name) > 0 {
+		return tag.name
+	}
+
+	return fallback
+}
+
+// isStructGroupType tests whether the given type is a struct that cannot itself
+// be used as a binding, meaning a tagged field of that type should be treated
+// as a flag group.
+func isStructGroupType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !unmarshal.IsUnmarshalable(reflect.PointerTo(t), unmarshalerType)
+}
+
+func (s *structTag) validate() error {
+	switch s.kind {
+	case structTagKindFlag:
+		if len(s.aliases) > 0 {
+			return errors.New("struct tag key \"alias\" is not valid for flags")
+		}
+
+	case structTagKindArgument:
+		if s.short != 0 || len(s.long) > 0 || len(s.aliases) > 0 || s.argOptional {
+			return errors.New("struct tag keys \"short\", \"long\", \"alias\", and \"arg-optional\" are not valid for positional arguments")
+		}
+
+	case structTagKindFlagGroup, structTagKindLeaf, structTagKindBranch:
+		if s.short != 0 || len(s.long) > 0 || len(s.env) > 0 || s.hasDefault || s.required || s.argOptional {
+			return errors.New("struct tag keys \"short\", \"long\", \"env\", \"default\", \"required\", and \"arg-optional\" are only valid for flags and arguments")
+		}
+
+		if s.kind == structTagKindFlagGroup && len(s.aliases) > 0 {
+			return errors.New("struct tag key \"alias\" is not valid for flag groups")
+		}
+	}
+
+	return nil
+}
+
+// A StructFieldError is returned by CommandFromStruct and CommandTreeFromStruct
+// when a struct field or its tag could not be converted into a CLI component.
+type StructFieldError interface {
+	error
+
+	// Field returns the dot separated path to the offending struct field.
+	Field() string
+
+	Unwrap() error
+}
+
+func newStructFieldError(field string, root error) StructFieldError {
+	return structFieldError{field, root}
+}
+
+type structFieldError struct {
+	field string
+	root  error
+}
+
+func (s structFieldError) Error() string {
+	return fmt.Sprintf("StructFieldError (%s): %s", s.field, s.root)
+}
+
+func (s structFieldError) Field() string {
+	return s.field
+}
+
+func (s structFieldError) Unwrap() error {
+	return s.root
+}
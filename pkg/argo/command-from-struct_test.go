@@ -0,0 +1,200 @@
+package argo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+func TestCommandFromStruct01(t *testing.T) {
+	type Output struct {
+		Format string `argo:"short=f,long=format,desc=Output format\\, one of json or yaml."`
+		Pretty bool   `argo:"short=p"`
+	}
+
+	type Config struct {
+		Verbose   bool          `argo:"short=v,long=verbose,desc=Enable verbose logging."`
+		MaxLines  int           `argo:"short=m,required"`
+		Timeout   time.Duration `argo:"default=30s"`
+		Output    Output        `argo:"name=Output Flags"`
+		InputFile string        `argo:"arg,name=file,required"`
+		Ignored   string
+	}
+
+	var config Config
+
+	builder, err := argo.CommandFromStruct(&config)
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	com, err := builder.Parse([]string{"command", "-v", "-m", "12", "--format", "json", "-p", "foo.txt"})
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !config.Verbose {
+		t.Error("expected Verbose to be true")
+	}
+
+	if config.MaxLines != 12 {
+		t.Error("expected MaxLines to be 12 but was", config.MaxLines)
+	}
+
+	if config.Timeout != 30*time.Second {
+		t.Error("expected Timeout to be 30s but was", config.Timeout)
+	}
+
+	if config.Output.Format != "json" {
+		t.Error("expected Output.Format to be json but was", config.Output.Format)
+	}
+
+	if !config.Output.Pretty {
+		t.Error("expected Output.Pretty to be true")
+	}
+
+	if config.InputFile != "foo.txt" {
+		t.Error("expected InputFile to be foo.txt but was", config.InputFile)
+	}
+
+	if flag := com.FindLongFlag("format"); flag == nil {
+		t.Error("expected format flag to exist")
+	} else if flag.Description() != "Output format, one of json or yaml." {
+		t.Error("expected escaped comma in description but got", flag.Description())
+	}
+
+	if com.FindLongFlag("ignored") != nil {
+		t.Error("expected untagged field to be ignored")
+	}
+}
+
+// Required flag from tag is enforced.
+func TestCommandFromStruct02(t *testing.T) {
+	type Config struct {
+		MaxLines int `argo:"short=m,required"`
+	}
+
+	var config Config
+
+	builder, err := argo.CommandFromStruct(&config)
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if _, err = builder.Parse([]string{"command"}); err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
+
+// Invalid tags are reported with the field path.
+func TestCommandFromStruct03(t *testing.T) {
+	type Config struct {
+		Bad string `argo:"short=xyz"`
+	}
+
+	var config Config
+
+	_, err := argo.CommandFromStruct(&config)
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var sfe argo.StructFieldError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &sfe) {
+		t.Error("expected a StructFieldError but got", err)
+	} else if sfe.Field() != "Config.Bad" {
+		t.Error("expected field path to be Config.Bad but was", sfe.Field())
+	}
+}
+
+// Non-pointer config values are rejected.
+func TestCommandFromStruct04(t *testing.T) {
+	type Config struct{}
+
+	if _, err := argo.CommandFromStruct(Config{}); err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
+
+// Leaves are not permitted on single commands.
+func TestCommandFromStruct05(t *testing.T) {
+	type Leaf struct{}
+	type Config struct {
+		Leaf Leaf `argo:"leaf"`
+	}
+
+	var config Config
+
+	if _, err := argo.CommandFromStruct(&config); err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
+
+func TestCommandTreeFromStruct01(t *testing.T) {
+	type Build struct {
+		Output string `argo:"short=o,long=output"`
+		Target string `argo:"arg,required"`
+	}
+
+	type Remove struct {
+		Force bool     `argo:"short=f"`
+		Files []string `argo:"arg"`
+	}
+
+	type Files struct {
+		Remove Remove `argo:"leaf,alias=rm"`
+	}
+
+	type Config struct {
+		Verbose bool  `argo:"short=v"`
+		Build   Build `argo:"leaf,desc=Builds the target."`
+		Files   Files `argo:"branch"`
+	}
+
+	var config Config
+
+	builder, err := argo.CommandTreeFromStruct(&config)
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	tree, err := builder.Parse([]string{"command", "-v", "build", "-o", "out", "thing"})
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if tree.SelectedCommand().Name() != "build" {
+		t.Error("expected selected command to be build but was", tree.SelectedCommand().Name())
+	}
+
+	if !config.Verbose {
+		t.Error("expected Verbose to be true")
+	}
+
+	if config.Build.Output != "out" {
+		t.Error("expected Build.Output to be out but was", config.Build.Output)
+	}
+
+	if config.Build.Target != "thing" {
+		t.Error("expected Build.Target to be thing but was", config.Build.Target)
+	}
+
+	builder, err = argo.CommandTreeFromStruct(&config)
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if _, err = builder.Parse([]string{"command", "files", "rm", "-f", "a,b"}); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !config.Files.Remove.Force {
+		t.Error("expected Files.Remove.Force to be true")
+	}
+
+	if len(config.Files.Remove.Files) != 2 {
+		t.Error("expected Files.Remove.Files to contain 2 values but had", len(config.Files.Remove.Files))
+	}
+}
@@ -0,0 +1,81 @@
+package argo
+
+import (
+	"context"
+)
+
+// CommandPreRunHook defines the function type for a hook that may be attached
+// to a CommandTree or CommandBranch to be run before the handler of any
+// descendant CommandLeaf.
+//
+// If a pre-run hook returns an error, the remaining pre-run hooks and the leaf
+// handler will not be called, and the error will be passed to the post-run
+// hooks.
+type CommandPreRunHook = func(ctx context.Context, leaf CommandLeaf) error
+
+// CommandPostRunHook defines the function type for a hook that may be attached
+// to a CommandTree or CommandBranch to be run after the handler of any
+// descendant CommandLeaf.
+//
+// Post-run hooks are always called, even if a pre-run hook or the leaf handler
+// failed, in which case that failure is passed to the hook as err.  The error
+// returned by the hook replaces the given error, so hooks that do not wish to
+// change the outcome of the call should return err unchanged.
+type CommandPostRunHook = func(ctx context.Context, leaf CommandLeaf, err error) error
+
+// CommandLeafMiddleware defines the function type for middleware that may be
+// attached to a CommandTree or CommandBranch to wrap the handler of every
+// descendant CommandLeaf.
+//
+// Example:
+//     func timing(next argo.CommandLeafHandler) argo.CommandLeafHandler {
+//         return func(ctx context.Context, leaf argo.CommandLeaf) error {
+//             start := time.Now()
+//             defer func() { log.Println(leaf.Name(), "took", time.Since(start)) }()
+//             return next(ctx, leaf)
+//         }
+//     }
+type CommandLeafMiddleware = func(next CommandLeafHandler) CommandLeafHandler
+
+// commandHooks holds the pre-run hooks, post-run hooks, and middleware attached
+// to a single CommandParent node.
+type commandHooks struct {
+	preRun     []CommandPreRunHook
+	postRun    []CommandPostRunHook
+	middleware []CommandLeafMiddleware
+}
+
+// wrap wraps the given handler with the hooks and middleware of a single node.
+//
+// The middleware is applied in the order it was added, with the first
+// middleware being the outermost, and wraps the node's pre-run hooks, the given
+// handler, and the node's post-run hooks.
+func (h commandHooks) wrap(next CommandLeafHandler) CommandLeafHandler {
+	handler := next
+
+	if len(h.preRun) > 0 || len(h.postRun) > 0 {
+		handler = func(ctx context.Context, leaf CommandLeaf) (err error) {
+			for _, hook := range h.preRun {
+				if err = hook(ctx, leaf); err != nil {
+					break
+				}
+			}
+
+			if err == nil {
+				err = next(ctx, leaf)
+			}
+
+			for _, hook := range h.postRun {
+				err = hook(ctx, leaf, err)
+			}
+
+			return err
+		}
+	}
+
+	for i := len(h.middleware) - 1; i >= 0; i-- {
+		handler = h.middleware[i](handler)
+	}
+
+	return handler
+}
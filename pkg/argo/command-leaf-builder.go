@@ -3,7 +3,6 @@ package argo
 import (
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/Foxcapades/Argonaut/internal/chars"
 	"github.com/Foxcapades/Argonaut/internal/util"
@@ -52,9 +51,86 @@ type CommandLeafBuilder interface {
 	// that were set on parent nodes.
 	WithCallback(cb CommandLeafCallback) CommandLeafBuilder
 
+	// WithHandler sets a handler on the CommandLeaf to be built that will be
+	// executed by CommandTreeBuilder.Execute when the command leaf is used.
+	//
+	// Unlike callbacks, handlers receive a context that is cancelled when the
+	// process receives SIGINT or SIGTERM, and may return an error that will be
+	// returned to the caller of Execute.
+	//
+	// Example:
+	//     cli.Leaf("serve").
+	//         WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+	//             return server.ListenAndServe(ctx)
+	//         })
+	WithHandler(handler CommandLeafHandler) CommandLeafBuilder
+
 	// WithArgument adds a positional argument to the CommandLeaf being built.
 	WithArgument(argument ArgumentBuilder) CommandLeafBuilder
 
+	// WithInterspersedDisabled disables the use of flags after positional
+	// arguments for the CommandLeaf being built.
+	//
+	// When disabled, the first positional argument passed to the CommandLeaf
+	// ends flag parsing, and it and every input following it are treated as
+	// positional arguments, even if they look like flags.
+	//
+	// Example:
+	//     cli.Leaf("exec").
+	//         WithInterspersedDisabled().
+	//         WithArgument(cli.Argument().WithName("command")).
+	//         WithUnmappedLabel("[ARGS...]")
+	//
+	// Example Usage:
+	//     $ my-app exec ls -la
+	WithInterspersedDisabled() CommandLeafBuilder
+
+	// WithStrictParsing enables strict parsing for the CommandLeaf being built.
+	//
+	// In strict mode, unrecognized flags, and positional arguments that are not
+	// accepted by any of the CommandLeaf's arguments, cause parsing to fail with
+	// a ParseError instead of being recorded as warnings and unmapped inputs.
+	//
+	// If an unmapped label has been set with WithUnmappedLabel, extra positional
+	// arguments are still collected as unmapped inputs.
+	//
+	// Example:
+	//     cli.Leaf("copy").
+	//         WithStrictParsing().
+	//         WithArgument(cli.Argument().WithName("source")).
+	//         WithArgument(cli.Argument().WithName("target"))
+	//
+	// Example Usage:
+	//     $ my-app copy a b c
+	//     unexpected positional argument "c"
+	WithStrictParsing() CommandLeafBuilder
+
+	// Hidden hides the CommandLeaf being built from rendered help text,
+	// documentation, and shell completions.
+	//
+	// Hidden leaves may still be used in a CLI call.
+	Hidden() CommandLeafBuilder
+
+	// Deprecated marks the CommandLeaf being built as deprecated.
+	//
+	// When a deprecated leaf is used in a CLI call, a warning including the
+	// given message is appended to the command tree's warnings.
+	Deprecated(message string) CommandLeafBuilder
+
+	// ReplacedBy names a sibling subcommand that will be used in place of the
+	// deprecated CommandLeaf being built when it appears in a CLI call.
+	//
+	// The CommandLeaf being built must also be marked as deprecated.
+	//
+	// Example:
+	//     cli.Tree().
+	//         WithLeaf(cli.Leaf("remove")).
+	//         WithLeaf(cli.Leaf("delete").
+	//             Hidden().
+	//             Deprecated("use remove instead").
+	//             ReplacedBy("remove"))
+	ReplacedBy(name string) CommandLeafBuilder
+
 	// WithFlagGroup adds a new FlagGroup to this CommandLeaf being built.
 	WithFlagGroup(flagGroup FlagGroupBuilder) CommandLeafBuilder
 
@@ -85,6 +161,15 @@ type commandLeafBuilder struct {
 	arguments   []ArgumentBuilder
 	flagGroups  []FlagGroupBuilder
 	callback    CommandLeafCallback
+	handler     CommandLeafHandler
+
+	noIntersperse bool
+	strict        bool
+
+	hidden      bool
+	deprecated  bool
+	deprecation string
+	replacement string
 }
 
 // PUBLIC API //////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -124,6 +209,37 @@ func (l *commandLeafBuilder) WithCallback(cb CommandLeafCallback) CommandLeafBui
 	return l
 }
 
+func (l *commandLeafBuilder) WithHandler(handler CommandLeafHandler) CommandLeafBuilder {
+	l.handler = handler
+	return l
+}
+
+func (l *commandLeafBuilder) WithInterspersedDisabled() CommandLeafBuilder {
+	l.noIntersperse = true
+	return l
+}
+
+func (l *commandLeafBuilder) WithStrictParsing() CommandLeafBuilder {
+	l.strict = true
+	return l
+}
+
+func (l *commandLeafBuilder) Hidden() CommandLeafBuilder {
+	l.hidden = true
+	return l
+}
+
+func (l *commandLeafBuilder) Deprecated(message string) CommandLeafBuilder {
+	l.deprecated = true
+	l.deprecation = message
+	return l
+}
+
+func (l *commandLeafBuilder) ReplacedBy(name string) CommandLeafBuilder {
+	l.replacement = name
+	return l
+}
+
 func (l *commandLeafBuilder) WithHelpDisabled() CommandLeafBuilder {
 	l.disableHelp = true
 	return l
@@ -158,6 +274,10 @@ func (l *commandLeafBuilder) Build(ctx *WarningContext) (CommandLeaf, error) {
 		}
 	}
 
+	if len(l.replacement) > 0 && !l.deprecated {
+		errs.AppendError(errors.New("replacement set on a command leaf that is not deprecated"))
+	}
+
 	if l.parentNode == nil {
 		panic("illegal state: attempted to build a command leaf with no parent set")
 	}
@@ -165,6 +285,8 @@ func (l *commandLeafBuilder) Build(ctx *WarningContext) (CommandLeaf, error) {
 	leaf := new(commandLeaf)
 	leaf.warnings = ctx
 
+	prepareVariadicArguments(l.arguments, errs)
+
 	forceRequiredUntil := 0
 	for i, builder := range l.arguments {
 		if builder.isRequired() {
@@ -245,7 +367,14 @@ func (l *commandLeafBuilder) Build(ctx *WarningContext) (CommandLeaf, error) {
 	leaf.aliases = l.aliases
 	leaf.parent = l.parentNode
 	leaf.callback = l.callback
+	leaf.handler = l.handler
 	leaf.uLabel = l.umapLabel
+	leaf.noIntersperse = l.noIntersperse
+	leaf.strict = l.strict
+	leaf.hidden = l.hidden
+	leaf.deprecated = l.deprecated
+	leaf.deprecation = l.deprecation
+	leaf.replacement = l.replacement
 
 	return leaf, nil
 }
@@ -254,8 +383,9 @@ func makeLeafHelp(short, long bool, leaf CommandLeaf) FlagBuilder {
 	builder := NewFlagBuilder().
 		setIsHelpFlag().
 		WithCallback(func(flag Flag) {
-			util.Must(comLeafRenderer{}.RenderHelp(leaf, os.Stdout))
-			os.Exit(0)
+			rt := nodeRuntime(leaf)
+			util.Must(comLeafRenderer{}.RenderHelp(leaf, rt.stdout()))
+			rt.exit(0)
 		}).
 		WithDescription("Prints this help text.")
 
@@ -1,5 +1,9 @@
 package argo
 
+import (
+	"context"
+)
+
 // A CommandLeaf is the final node in a CommandTree branch.
 //
 // Command leaves may be children of either a CommandTree directly, or of a
@@ -25,6 +29,15 @@ type CommandLeaf interface {
 // CommandLeaf is used in a CLI call.
 type CommandLeafCallback = func(leaf CommandLeaf)
 
+// CommandLeafHandler defines the function type for a handler function that may
+// be attached to a CommandLeaf.
+//
+// A CommandLeaf's handler is called by CommandTreeBuilder.Execute if and when
+// the CommandLeaf is used in a CLI call, after all callbacks have been
+// executed.  The error returned by the handler is returned to the caller of
+// Execute.
+type CommandLeafHandler = func(ctx context.Context, leaf CommandLeaf) error
+
 type commandLeaf struct {
 	name        string
 	desc        string
@@ -37,6 +50,15 @@ type commandLeaf struct {
 	passthrough []string
 	warnings    *WarningContext
 	callback    CommandLeafCallback
+	handler     CommandLeafHandler
+
+	noIntersperse bool
+	strict        bool
+
+	hidden      bool
+	deprecated  bool
+	deprecation string
+	replacement string
 }
 
 func (c commandLeaf) Parent() CommandNode { return c.parent }
@@ -50,6 +72,37 @@ func (c commandLeaf) executeCallback() {
 	}
 }
 
+// executeHandler executes the handler function attached to this command leaf,
+// wrapped by the hooks and middleware of each of its parent nodes.
+//
+// The hooks of parent nodes closer to the root of the tree wrap the hooks of
+// the nodes beneath them.
+func (c *commandLeaf) executeHandler(ctx context.Context) error {
+	handler := func(ctx context.Context, leaf CommandLeaf) error {
+		if c.handler != nil {
+			return c.handler(ctx, leaf)
+		}
+
+		return nil
+	}
+
+	var node CommandNode = c
+	for node.HasParent() {
+		node = node.Parent()
+		handler = node.(CommandParent).wrapHandler(handler)
+	}
+
+	return handler(ctx, c)
+}
+
+func (c commandLeaf) allowsInterspersed() bool {
+	return !c.noIntersperse && nodeAllowsInterspersed(&c)
+}
+
+func (c commandLeaf) strictParsing() bool {
+	return c.strict || nodeStrictParsing(&c)
+}
+
 func (c commandLeaf) hasCallback() bool {
 	return c.callback != nil
 }
@@ -95,12 +148,17 @@ func (c commandLeaf) HasArguments() bool {
 }
 
 func (c *commandLeaf) appendArgument(val string) error {
-	for _, arg := range c.args {
-		if !arg.WasHit() {
+	for i, arg := range c.args {
+		if arg.acceptsValue() {
+			useArgument(arg, i)
 			return arg.setValue(val)
 		}
 	}
 
+	if c.strictParsing() && !c.HasUnmappedLabel() {
+		return newTooManyArgumentsError(val)
+	}
+
 	c.appendUnmapped(val)
 	return nil
 }
@@ -129,6 +187,26 @@ func (c *commandLeaf) appendPassthrough(val string) {
 	c.passthrough = append(c.passthrough, val)
 }
 
+func (c commandLeaf) IsHidden() bool {
+	return c.hidden
+}
+
+func (c commandLeaf) IsDeprecated() bool {
+	return c.deprecated
+}
+
+func (c commandLeaf) DeprecationMessage() string {
+	return c.deprecation
+}
+
+func (c commandLeaf) HasReplacement() bool {
+	return len(c.replacement) > 0
+}
+
+func (c commandLeaf) Replacement() string {
+	return c.replacement
+}
+
 func (c commandLeaf) Matches(name string) bool {
 	if c.name == name {
 		return true
@@ -154,13 +232,33 @@ func (c commandLeaf) FindShortFlag(b byte) Flag {
 }
 
 func (c commandLeaf) FindLongFlag(name string) Flag {
-	for _, group := range c.FlagGroups() {
-		if flag := group.FindLongFlag(name); flag != nil {
-			return flag
-		}
-	}
+	flag, _ := c.resolveLongFlag(name)
+	return flag
+}
+
+func (c commandLeaf) resolveLongFlag(name string) (Flag, error) {
+	return lookupLongFlag(nodeFlagGroups(&c), name, nodeAllowsAbbreviations(&c))
+}
 
-	return c.parent.FindLongFlag(name)
+func (c commandLeaf) findExactLongFlag(name string) Flag {
+	flag, _ := lookupLongFlag(nodeFlagGroups(&c), name, false)
+	return flag
+}
+
+// getConfigLoader returns nil as configuration files are loaded by the
+// CommandTree a leaf belongs to.
+func (c commandLeaf) getConfigLoader() *configLoader {
+	return nil
+}
+
+// expandsResponseFiles returns false as response files are expanded by the
+// CommandTree a leaf belongs to.
+func (c commandLeaf) expandsResponseFiles() bool {
+	return false
+}
+
+func (c commandLeaf) getRuntime() *Runtime {
+	return nodeRuntime(c.parent)
 }
 
 func (c commandLeaf) Warnings() []string {
@@ -170,3 +268,11 @@ func (c commandLeaf) Warnings() []string {
 func (c commandLeaf) AppendWarning(warning string) {
 	c.warnings.appendWarning(warning)
 }
+
+func (c commandLeaf) appendWarningError(err error) {
+	c.warnings.appendWarningError(err)
+}
+
+func (c commandLeaf) suggestionThreshold() int {
+	return nodeSuggestionThreshold(&c)
+}
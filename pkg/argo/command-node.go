@@ -53,9 +53,25 @@ type CommandNode interface {
 	//
 	// If no such flag exists on this CommandNode or any of its parents, this
 	// method will return nil.
+	//
+	// If abbreviations are enabled on the CommandTree, and no flag has exactly
+	// the given name, a flag whose long-form name is uniquely prefixed by the
+	// given name will be returned.
 	FindLongFlag(name string) Flag
 
+	// resolveLongFlag looks up a target Flag instance by its long-form name,
+	// returning an error if abbreviations are enabled and the given name is an
+	// ambiguous prefix.
+	resolveLongFlag(name string) (Flag, error)
+
+	// findExactLongFlag looks up a Flag instance by its full long form name,
+	// ignoring abbreviations.
+	findExactLongFlag(name string) Flag
+
 	Warnings() []string
 
 	AppendWarning(warning string)
+
+	// appendWarningError appends a warning that carries additional details.
+	appendWarningError(err error)
 }
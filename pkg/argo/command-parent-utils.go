@@ -1,19 +1,84 @@
 package argo
 
 import (
-	"os"
+	"strings"
 
 	"github.com/Foxcapades/Argonaut/internal/util"
 )
 
+// lookupChild looks up a subcommand by name or alias in the given command
+// groups, in order.
+//
+// If no subcommand has exactly the given name or alias and abbreviations are
+// enabled, the subcommand whose name or one of whose aliases is uniquely
+// prefixed by the given name is returned instead.  If more than one subcommand
+// is prefixed by the given name, an AmbiguousPrefixError is returned.  Hidden
+// subcommands are only matched by their full name or aliases.
+func lookupChild(groups []CommandGroup, name string, abbreviations bool) (CommandChild, error) {
+	for _, group := range groups {
+		if child := group.FindChild(name); child != nil {
+			return child, nil
+		}
+	}
+
+	if !abbreviations || len(name) == 0 {
+		return nil, nil
+	}
+
+	var match CommandChild
+	candidates := make([]string, 0, 4)
+
+	test := func(child CommandChild) {
+		if !child.IsHidden() && childHasPrefix(child, name) {
+			match = child
+			candidates = append(candidates, child.Name())
+		}
+	}
+
+	for _, group := range groups {
+		for _, branch := range group.Branches() {
+			test(branch)
+		}
+
+		for _, leaf := range group.Leaves() {
+			test(leaf)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return match, nil
+	default:
+		return nil, newAmbiguousPrefixError(name, candidates, false)
+	}
+}
+
+func childHasPrefix(child CommandChild, prefix string) bool {
+	if strings.HasPrefix(child.Name(), prefix) {
+		return true
+	}
+
+	for _, alias := range child.Aliases() {
+		if strings.HasPrefix(alias, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func defaultOnIncompleteHandler(parent CommandParent) {
+	rt := nodeRuntime(parent)
+
 	if tree, ok := parent.(CommandTree); ok {
-		util.Must(comTreeRenderer{}.RenderHelp(tree, os.Stderr))
+		util.Must(comTreeRenderer{}.RenderHelp(tree, rt.stderr()))
 	} else if branch, ok := parent.(CommandBranch); ok {
-		util.Must(comBranchRenderer{}.RenderHelp(branch, os.Stderr))
+		util.Must(comBranchRenderer{}.RenderHelp(branch, rt.stderr()))
 	} else {
 		panic("illegal state: unrecognized command parent implementation")
 	}
 
-	os.Exit(1)
+	rt.exit(1)
 }
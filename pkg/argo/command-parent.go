@@ -13,7 +13,20 @@ type CommandParent interface {
 	// subcommand that matches the given string.
 	//
 	// A subcommand may match on either its name or one of its aliases.
+	//
+	// If abbreviations are enabled on the CommandTree, and no subcommand matches
+	// the given string exactly, a subcommand whose name or alias is uniquely
+	// prefixed by the given string will be returned.
 	FindChild(name string) CommandChild
 
+	// resolveChild searches this CommandParent's CommandGroup instances for a
+	// subcommand that matches the given string, returning an error if
+	// abbreviations are enabled and the given string is an ambiguous prefix.
+	resolveChild(name string) (CommandChild, error)
+
 	onIncomplete(node CommandParent)
+
+	// wrapHandler wraps the given handler with the pre-run hooks, post-run hooks,
+	// and middleware attached to this CommandParent node.
+	wrapHandler(next CommandLeafHandler) CommandLeafHandler
 }
@@ -1,8 +1,8 @@
 package argo
 
 import (
+	"context"
 	"errors"
-	"os"
 
 	"github.com/Foxcapades/Argonaut/internal/chars"
 	"github.com/Foxcapades/Argonaut/internal/util"
@@ -44,6 +44,39 @@ type CommandTreeBuilder interface {
 	// the command segments appear in the CLI call.
 	WithCallback(cb CommandTreeCallback) CommandTreeBuilder
 
+	// WithPreRun appends a hook that will be run by Execute before the handler of
+	// any CommandLeaf beneath the CommandTree being built.
+	//
+	// Pre-run hooks are called in the order they were added, after the pre-run
+	// hooks of any parent nodes.  If a pre-run hook returns an error, the
+	// remaining pre-run hooks and the leaf handler will not be called.
+	WithPreRun(hook CommandPreRunHook) CommandTreeBuilder
+
+	// WithPostRun appends a hook that will be run by Execute after the handler of
+	// any CommandLeaf beneath the CommandTree being built.
+	//
+	// Post-run hooks are called in the order they were added, before the
+	// post-run hooks of any parent nodes.  Post-run hooks are always called, even
+	// when a pre-run hook or the leaf handler fails, and receive that failure.
+	WithPostRun(hook CommandPostRunHook) CommandTreeBuilder
+
+	// WithMiddleware appends the given middleware to the chain that wraps the
+	// handler of every CommandLeaf beneath the CommandTree being built.
+	//
+	// The first middleware added is the outermost.  The middleware of a node
+	// wraps that node's pre-run and post-run hooks, as well as the hooks and
+	// middleware of any child nodes.
+	//
+	// Example:
+	//     cli.Tree().
+	//         WithMiddleware(func(next argo.CommandLeafHandler) argo.CommandLeafHandler {
+	//             return func(ctx context.Context, leaf argo.CommandLeaf) error {
+	//                 log.Println("running", leaf.Name())
+	//                 return next(ctx, leaf)
+	//             }
+	//         })
+	WithMiddleware(middleware ...CommandLeafMiddleware) CommandTreeBuilder
+
 	// WithHelpDisabled disables the automatic `-h` and `--help` flags for
 	// rendering help text.
 	WithHelpDisabled() CommandTreeBuilder
@@ -90,15 +123,171 @@ type CommandTreeBuilder interface {
 	// handler set.
 	OnIncomplete(handler OnIncompleteHandler) CommandTreeBuilder
 
+	// WithConfigFile sets the path to a configuration file that flag values will
+	// be loaded from.
+	//
+	// Configuration file values are keyed by flag long-form name, and are passed
+	// through each flag argument's unmarshaler and validators exactly like values
+	// passed on the command line.  The order of precedence for flag values is:
+	// CLI input, then environment variables, then the configuration file, then
+	// defaults.
+	//
+	// Files ending in ".json" are parsed as JSON, files ending in ".yaml" or
+	// ".yml" are parsed as a subset of YAML, and all other files are parsed as
+	// INI.
+	//
+	// If the file does not exist, it will be ignored.
+	//
+	// Example Config File:
+	//     timeout = 30s
+	//     verbose = true
+	//     labels = [a=1, b=2]
+	//
+	// For command trees, flags belonging to subcommands are keyed under nested
+	// sections named for the path to the subcommand:
+	//     [branch.leaf]
+	//     dry-run = true
+	WithConfigFile(path string) CommandTreeBuilder
+
+	// WithConfigFlag adds a flag with the given long-form name that may be used
+	// to provide the path to a configuration file on the CLI.
+	//
+	// A path given by the flag takes priority over the path set with
+	// WithConfigFile.  Unlike the default configuration file path, if the path
+	// given by the flag does not exist, an error will be returned.
+	//
+	// See WithConfigFile for details on configuration files.
+	WithConfigFlag(name string) CommandTreeBuilder
+
+	// WithRuntime sets the Runtime that the built CommandTree will use for writing
+	// help text, exiting, determining the program name, and looking up
+	// environment variables.
+	//
+	// If no Runtime is set, the default process environment will be used.
+	WithRuntime(rt *Runtime) CommandTreeBuilder
+
+	// WithAbbreviations enables abbreviated long flags and subcommand names on the CLI.
+	//
+	// When enabled, a long flag that does not exactly match any flag available
+	// to the command node being called may be any unique prefix of one of those flags'
+	// long forms, for example `--verb` for `--verbose`.  If the prefix matches
+	// more than one flag, an AmbiguousPrefixError listing the candidates will be
+	// returned.
+	//
+	// The same applies to subcommand names and aliases, for example `my-app st`
+	// for `my-app status`.
+	//
+	// Negated flag forms, and the flag names referenced by other flags, for
+	// example via RequiresFlag or ReplacedBy, are never abbreviated and must use
+	// the full long form.
+	WithAbbreviations() CommandTreeBuilder
+
+	// WithResponseFiles enables response file expansion for the CommandTree being
+	// built.
+	//
+	// When enabled, any CLI argument of the form `@path` is replaced with the
+	// arguments read from the file at that path before the CLI call is parsed.
+	// Arguments in a response file are separated by whitespace, may be quoted
+	// with single or double quotes, and may have characters escaped with a
+	// backslash.  Lines starting with '#' are ignored.
+	//
+	// Response files may reference other response files, with relative paths
+	// being resolved against the directory of the referencing file.  The path
+	// `@-` reads arguments from the Runtime's stdin.  Arguments following an
+	// end-of-arguments boundary, "--", are not expanded.
+	//
+	// Example:
+	//     $ cat args.rsp
+	//     --define 'name=My App'
+	//     @common.rsp
+	//     $ my-app @args.rsp build
+	WithResponseFiles() CommandTreeBuilder
+
+	// WithInterspersedDisabled disables the use of flags after positional
+	// arguments for every CommandLeaf in the CommandTree being built.
+	//
+	// When disabled, the first positional argument passed to a CommandLeaf ends
+	// flag parsing, and it and every input following it are treated as
+	// positional arguments of that leaf, even if they look like flags.  Flags
+	// may still be used between subcommand names.
+	//
+	// This mode is also enabled when the POSIXLY_CORRECT environment variable
+	// is set.
+	//
+	// See CommandLeafBuilder.WithInterspersedDisabled to disable interspersed
+	// flags for a single CommandLeaf.
+	WithInterspersedDisabled() CommandTreeBuilder
+
+	// WithSuggestionThreshold sets the maximum edit distance between an
+	// unrecognized subcommand or long flag and a known one for the known one to
+	// be suggested as an alternative.
+	//
+	// Edit distance is the number of single character insertions, deletions,
+	// substitutions, or transpositions needed to turn one name into the other.
+	// Defaults to DefaultSuggestionThreshold.  A threshold less than 1 disables
+	// suggestions.
+	WithSuggestionThreshold(distance int) CommandTreeBuilder
+
+	// WithStrictParsing enables strict parsing for every node in the CommandTree
+	// being built.
+	//
+	// In strict mode, unrecognized flags, and positional arguments that are not
+	// accepted by any of the selected CommandLeaf's arguments, cause parsing to
+	// fail with a ParseError instead of being recorded as warnings and unmapped
+	// inputs.  Unrecognized long flags are reported with suggestions of similar
+	// flags.
+	//
+	// CommandLeaf instances with an unmapped label set still collect extra
+	// positional arguments as unmapped inputs.
+	//
+	// See CommandLeafBuilder.WithStrictParsing to enable strict parsing for a
+	// single CommandLeaf.
+	WithStrictParsing() CommandTreeBuilder
+
 	Build(warnings *WarningContext) (CommandTree, error)
 
 	// Parse builds the command tree and attempts to parse the given CLI arguments
 	// into that command tree's components.
+	//
+	// If the first argument after the program name is the hidden
+	// CompletionCommandName subcommand, the remaining arguments will instead be
+	// interpreted in completion mode, the completion candidates for the last
+	// argument will be printed to the Runtime's stdout, and the program will
+	// exit.
 	Parse(args []string) (CommandTree, error)
 
 	// MustParse calls Parse and panics if an error is returned.
 	MustParse(args []string) CommandTree
 
+	// Execute builds the command tree, parses the given arguments, then calls
+	// the handler of the selected CommandLeaf, if it has one.
+	//
+	// The handler is passed a context derived from the given context that will
+	// be cancelled when the process receives SIGINT or SIGTERM.
+	//
+	// If building fails, the build error is returned.  If parsing fails, the
+	// parse error is returned wrapped in an ExitError with the code
+	// ExitCodeUsage.  Otherwise, the error returned by the handler is returned.
+	// Pass the returned error to ExitCode to determine the exit code the process
+	// should exit with.
+	//
+	// If help text is printed and the Runtime's exit function returns, an
+	// ExitError with the code ExitCodeSuccess is returned, so only errors with a
+	// non-zero exit code should be reported.
+	//
+	// Example:
+	//     func main() {
+	//         err := cli.Tree().
+	//             WithLeaf(cli.Leaf("serve").WithHandler(serve)).
+	//             Execute(context.Background(), os.Args)
+	//
+	//         if code := argo.ExitCode(err); code != 0 {
+	//             fmt.Fprintln(os.Stderr, err)
+	//             os.Exit(code)
+	//         }
+	//     }
+	Execute(ctx context.Context, args []string) error
+
 	hasSubCommands() bool
 }
 
@@ -106,6 +295,7 @@ func NewCommandTreeBuilder() CommandTreeBuilder {
 	return &commandTreeBuilder{
 		commandGroups: []CommandGroupBuilder{NewCommandGroupBuilder(chars.DefaultGroupName)},
 		flagGroups:    []FlagGroupBuilder{NewFlagGroupBuilder(chars.DefaultGroupName)},
+		suggestions:   DefaultSuggestionThreshold,
 	}
 }
 
@@ -115,6 +305,14 @@ type commandTreeBuilder struct {
 	commandGroups []CommandGroupBuilder
 	flagGroups    []FlagGroupBuilder
 	callback      CommandTreeCallback
+	hooks         commandHooks
+	config        configLoader
+	runtime       *Runtime
+	abbreviations bool
+	responseFiles bool
+	noIntersperse bool
+	suggestions   int
+	strict        bool
 
 	onIncompleteHandler OnIncompleteHandler
 }
@@ -129,6 +327,21 @@ func (t *commandTreeBuilder) WithCallback(cb CommandTreeCallback) CommandTreeBui
 	return t
 }
 
+func (t *commandTreeBuilder) WithPreRun(hook CommandPreRunHook) CommandTreeBuilder {
+	t.hooks.preRun = append(t.hooks.preRun, hook)
+	return t
+}
+
+func (t *commandTreeBuilder) WithPostRun(hook CommandPostRunHook) CommandTreeBuilder {
+	t.hooks.postRun = append(t.hooks.postRun, hook)
+	return t
+}
+
+func (t *commandTreeBuilder) WithMiddleware(middleware ...CommandLeafMiddleware) CommandTreeBuilder {
+	t.hooks.middleware = append(t.hooks.middleware, middleware...)
+	return t
+}
+
 func (t *commandTreeBuilder) WithHelpDisabled() CommandTreeBuilder {
 	t.helpDisabled = true
 	return t
@@ -164,6 +377,46 @@ func (t *commandTreeBuilder) OnIncomplete(handler OnIncompleteHandler) CommandTr
 	return t
 }
 
+func (t *commandTreeBuilder) WithConfigFile(path string) CommandTreeBuilder {
+	t.config.path = path
+	return t
+}
+
+func (t *commandTreeBuilder) WithConfigFlag(name string) CommandTreeBuilder {
+	t.config.flag = name
+	return t
+}
+
+func (t *commandTreeBuilder) WithRuntime(rt *Runtime) CommandTreeBuilder {
+	t.runtime = rt
+	return t
+}
+
+func (t *commandTreeBuilder) WithAbbreviations() CommandTreeBuilder {
+	t.abbreviations = true
+	return t
+}
+
+func (t *commandTreeBuilder) WithResponseFiles() CommandTreeBuilder {
+	t.responseFiles = true
+	return t
+}
+
+func (t *commandTreeBuilder) WithInterspersedDisabled() CommandTreeBuilder {
+	t.noIntersperse = true
+	return t
+}
+
+func (t *commandTreeBuilder) WithSuggestionThreshold(distance int) CommandTreeBuilder {
+	t.suggestions = distance
+	return t
+}
+
+func (t *commandTreeBuilder) WithStrictParsing() CommandTreeBuilder {
+	t.strict = true
+	return t
+}
+
 func (t commandTreeBuilder) Parse(args []string) (CommandTree, error) {
 	ctx := new(WarningContext)
 	ct, err := t.Build(ctx)
@@ -171,8 +424,7 @@ func (t commandTreeBuilder) Parse(args []string) (CommandTree, error) {
 		return nil, err
 	}
 
-	err = newCommandTreeInterpreter(args, ct).Run()
-	if err != nil {
+	if err = ParseCommandTree(ct, args); err != nil {
 		return nil, err
 	}
 
@@ -180,10 +432,16 @@ func (t commandTreeBuilder) Parse(args []string) (CommandTree, error) {
 }
 
 func (t commandTreeBuilder) MustParse(args []string) CommandTree {
-	ctx := new(WarningContext)
-	ct := util.MustReturn(t.Build(ctx))
-	util.Must(newCommandTreeInterpreter(args, ct).Run())
-	return ct
+	return util.MustReturn(t.Parse(args))
+}
+
+func (t commandTreeBuilder) Execute(ctx context.Context, args []string) error {
+	ct, err := t.Build(new(WarningContext))
+	if err != nil {
+		return err
+	}
+
+	return ExecuteCommandTree(ctx, ct, args)
 }
 
 func (t *commandTreeBuilder) hasSubCommands() bool {
@@ -200,11 +458,16 @@ func (t *commandTreeBuilder) Build(warnings *WarningContext) (CommandTree, error
 	errs := newMultiError()
 
 	tree := new(commandTree)
+	tree.runtime = t.runtime
 
 	if !t.hasSubCommands() {
 		errs.AppendError(errors.New("command tree has no subcommands"))
 	}
 
+	if len(t.config.flag) > 0 {
+		t.flagGroups[0].WithFlag(t.config.makeFlag())
+	}
+
 	if !t.helpDisabled {
 		group := t.flagGroups[0]
 
@@ -269,8 +532,21 @@ func (t *commandTreeBuilder) Build(warnings *WarningContext) (CommandTree, error
 	tree.flagGroups = flagGroups
 	tree.commandGroups = commandGroups
 	tree.callback = t.callback
+	tree.hooks = t.hooks
+	tree.config = t.config
+	tree.abbreviations = t.abbreviations
+	tree.responseFiles = t.responseFiles
+	tree.noIntersperse = t.noIntersperse
+	tree.suggestions = t.suggestions
+	tree.strict = t.strict
 	tree.onIncompleteHandler = util.IfElse(t.onIncompleteHandler == nil, defaultOnIncompleteHandler, t.onIncompleteHandler)
 
+	validateTreeFlagReferences(tree, errs)
+	validateTreeReplacements(tree, errs)
+	if len(errs.Errors()) > 0 {
+		return nil, errs
+	}
+
 	return tree, nil
 }
 
@@ -278,8 +554,9 @@ func makeCommandTreeHelpFlag(short, long bool, tree CommandTree) FlagBuilder {
 	out := NewFlagBuilder().
 		setIsHelpFlag().
 		WithCallback(func(flag Flag) {
-			util.Must(comTreeRenderer{}.RenderHelp(tree, os.Stdout))
-			os.Exit(0)
+			rt := tree.getRuntime()
+			util.Must(comTreeRenderer{}.RenderHelp(tree, rt.stdout()))
+			rt.exit(0)
 		}).
 		WithDescription("Prints this help text.")
 
@@ -198,6 +198,19 @@ func TestCommandTreeBuilder_InvalidArgumentBindingAndDefault(t *testing.T) {
 }
 
 // Unrecognized short solo flag becomes a warning.
+func TestCommandTreeBuilder_AbbreviatedFlagReference(t *testing.T) {
+	_, err := cli.Tree().
+		WithAbbreviations().
+		WithFlag(cli.LongFlag("verbose")).
+		WithLeaf(cli.Leaf("build").
+			WithFlag(cli.LongFlag("loud").ReplacedBy("verb"))).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not be nil, but it was")
+	}
+}
+
 func TestCommandTreeBuilder_UnknownShortSoloWarning(t *testing.T) {
 	com := cli.Tree().
 		WithLeaf(cli.Leaf("leaf")).
@@ -333,3 +346,17 @@ func TestCommandTreeBuilder_Build06(t *testing.T) {
 		t.Log(err)
 	}
 }
+
+// Deprecated subcommand replaced by an unknown subcommand
+func TestCommandTreeBuilder_Build07(t *testing.T) {
+	_, err := cli.Tree().
+		WithLeaf(cli.Leaf("rm").Deprecated("").ReplacedBy("remove")).
+		WithLeaf(cli.Leaf("delete")).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err not to be nil but it was")
+	} else {
+		t.Log(err)
+	}
+}
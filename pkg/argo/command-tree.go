@@ -1,10 +1,5 @@
 package argo
 
-import (
-	"os"
-	"path/filepath"
-)
-
 // CommandTree represents the root of a tree of subcommands.
 //
 // The command tree consists of branch and leaf nodes.  The branch nodes can be
@@ -38,6 +33,20 @@ type CommandTree interface {
 	hasCallback() bool
 
 	executeCallback()
+
+	getConfigLoader() *configLoader
+
+	getRuntime() *Runtime
+
+	allowsAbbreviations() bool
+
+	expandsResponseFiles() bool
+
+	allowsInterspersed() bool
+
+	suggestionThreshold() int
+
+	strictParsing() bool
 }
 
 type CommandTreeCallback = func(com CommandTree)
@@ -49,13 +58,29 @@ type commandTree struct {
 	commandGroups []CommandGroup
 	selected      CommandLeaf
 	callback      CommandTreeCallback
+	hooks         commandHooks
 	warnings      *WarningContext
+	config        configLoader
+	runtime       *Runtime
+	abbreviations bool
+	responseFiles bool
+	noIntersperse bool
+	suggestions   int
+	strict        bool
 
 	onIncompleteHandler OnIncompleteHandler
 }
 
-func (_ commandTree) Name() string {
-	return filepath.Base(os.Args[0])
+func (t commandTree) Name() string {
+	return t.runtime.programName()
+}
+
+func (t commandTree) getRuntime() *Runtime {
+	return t.runtime
+}
+
+func (t *commandTree) getConfigLoader() *configLoader {
+	return &t.config
 }
 
 func (_ commandTree) Parent() CommandNode {
@@ -96,6 +121,10 @@ func (t commandTree) executeCallback() {
 	}
 }
 
+func (t commandTree) wrapHandler(next CommandLeafHandler) CommandLeafHandler {
+	return t.hooks.wrap(next)
+}
+
 func (t commandTree) SelectedCommand() CommandLeaf {
 	return t.selected
 }
@@ -104,14 +133,33 @@ func (t *commandTree) selectCommand(leaf CommandLeaf) {
 	t.selected = leaf
 }
 
+func (t commandTree) allowsAbbreviations() bool {
+	return t.abbreviations
+}
+
+func (t commandTree) expandsResponseFiles() bool {
+	return t.responseFiles
+}
+
+func (t commandTree) suggestionThreshold() int {
+	return t.suggestions
+}
+
+func (t commandTree) strictParsing() bool {
+	return t.strict
+}
+
+func (t commandTree) allowsInterspersed() bool {
+	return !t.noIntersperse && !t.runtime.posixlyCorrect()
+}
+
 func (t commandTree) FindChild(name string) CommandChild {
-	for _, group := range t.commandGroups {
-		if child := group.FindChild(name); child != nil {
-			return child
-		}
-	}
+	child, _ := t.resolveChild(name)
+	return child
+}
 
-	return nil
+func (t commandTree) resolveChild(name string) (CommandChild, error) {
+	return lookupChild(t.commandGroups, name, t.abbreviations)
 }
 
 func (t commandTree) FindShortFlag(b byte) Flag {
@@ -125,13 +173,17 @@ func (t commandTree) FindShortFlag(b byte) Flag {
 }
 
 func (t commandTree) FindLongFlag(name string) Flag {
-	for _, group := range t.FlagGroups() {
-		if flag := group.FindLongFlag(name); flag != nil {
-			return flag
-		}
-	}
+	flag, _ := t.resolveLongFlag(name)
+	return flag
+}
 
-	return nil
+func (t commandTree) resolveLongFlag(name string) (Flag, error) {
+	return lookupLongFlag(t.flagGroups, name, t.abbreviations)
+}
+
+func (t commandTree) findExactLongFlag(name string) Flag {
+	flag, _ := lookupLongFlag(t.flagGroups, name, false)
+	return flag
 }
 
 func (t commandTree) onIncomplete(node CommandParent) {
@@ -145,3 +197,7 @@ func (t *commandTree) Warnings() []string {
 func (t *commandTree) AppendWarning(warning string) {
 	t.warnings.appendWarning(warning)
 }
+
+func (t *commandTree) appendWarningError(err error) {
+	t.warnings.appendWarningError(err)
+}
@@ -1,12 +1,19 @@
 package argo
 
 import (
-	"os"
-	"path/filepath"
+	"context"
 )
 
 type CommandCallback = func(command Command)
 
+// CommandHandler defines the function type for a handler function that may be
+// attached to a Command.
+//
+// A Command's handler is called by CommandBuilder.Execute after the CLI call
+// has been parsed and all callbacks have been executed.  The error returned by
+// the handler is returned to the caller of Execute.
+type CommandHandler = func(ctx context.Context, command Command) error
+
 // Command represents a singular, non-nested command which accepts flags and
 // arguments.
 type Command interface {
@@ -42,8 +49,21 @@ type Command interface {
 	//
 	// If no such flag could be found on this command, this method will return
 	// nil.
+	//
+	// If abbreviations are enabled, and no flag has exactly the given name, a
+	// flag whose long form is uniquely prefixed by the given name will be
+	// returned.
 	FindLongFlag(name string) Flag
 
+	// resolveLongFlag looks up a Flag instance by its long form, returning an
+	// error if abbreviations are enabled and the given name is an ambiguous
+	// prefix.
+	resolveLongFlag(name string) (Flag, error)
+
+	// findExactLongFlag looks up a Flag instance by its full long form name,
+	// ignoring abbreviations.
+	findExactLongFlag(name string) Flag
+
 	// Arguments returns the positional Argument instances attached to this
 	// Command.
 	Arguments() []Argument
@@ -101,7 +121,32 @@ type Command interface {
 
 	AppendWarning(warning string)
 
+	// appendWarningError appends a warning that carries additional details.
+	appendWarningError(err error)
+
 	executeCallback()
+
+	// executeHandler executes the handler function attached to this command if
+	// it has one.
+	executeHandler(ctx context.Context) error
+
+	getConfigLoader() *configLoader
+
+	getRuntime() *Runtime
+
+	expandsResponseFiles() bool
+
+	// allowsInterspersed indicates whether flags may follow positional arguments
+	// in a CLI call to this command.
+	allowsInterspersed() bool
+
+	// suggestionThreshold returns the maximum edit distance used when suggesting
+	// alternatives for unrecognized flags.
+	suggestionThreshold() int
+
+	// strictParsing indicates whether unrecognized flags and unexpected
+	// positional arguments passed to this command are errors.
+	strictParsing() bool
 }
 
 type command struct {
@@ -113,10 +158,42 @@ type command struct {
 	unmapped      []string
 	passthrough   []string
 	callback      CommandCallback
+	handler       CommandHandler
+	config        configLoader
+	runtime       *Runtime
+	abbreviations bool
+	responseFiles bool
+	noIntersperse bool
+	suggestions   int
+	strict        bool
 }
 
 func (c command) Name() string {
-	return filepath.Base(os.Args[0])
+	return c.runtime.programName()
+}
+
+func (c command) getRuntime() *Runtime {
+	return c.runtime
+}
+
+func (c command) expandsResponseFiles() bool {
+	return c.responseFiles
+}
+
+func (c command) allowsInterspersed() bool {
+	return !c.noIntersperse && !c.runtime.posixlyCorrect()
+}
+
+func (c command) suggestionThreshold() int {
+	return c.suggestions
+}
+
+func (c command) strictParsing() bool {
+	return c.strict
+}
+
+func (c *command) getConfigLoader() *configLoader {
+	return &c.config
 }
 
 func (c command) Description() string {
@@ -154,13 +231,17 @@ func (c command) FindShortFlag(b byte) Flag {
 }
 
 func (c command) FindLongFlag(name string) Flag {
-	for _, group := range c.flagGroups {
-		if flag := group.FindLongFlag(name); flag != nil {
-			return flag
-		}
-	}
+	flag, _ := c.resolveLongFlag(name)
+	return flag
+}
 
-	return nil
+func (c command) resolveLongFlag(name string) (Flag, error) {
+	return lookupLongFlag(c.flagGroups, name, c.abbreviations)
+}
+
+func (c command) findExactLongFlag(name string) Flag {
+	flag, _ := lookupLongFlag(c.flagGroups, name, false)
+	return flag
 }
 
 func (c command) Arguments() []Argument {
@@ -172,12 +253,17 @@ func (c command) HasArguments() bool {
 }
 
 func (c *command) appendArgument(rawArgument string) error {
-	for _, arg := range c.arguments {
-		if !arg.WasHit() {
+	for i, arg := range c.arguments {
+		if arg.acceptsValue() {
+			useArgument(arg, i)
 			return arg.setValue(rawArgument)
 		}
 	}
 
+	if c.strict && !c.HasUnmappedLabel() {
+		return newTooManyArgumentsError(rawArgument)
+	}
+
 	c.unmapped = append(c.unmapped, rawArgument)
 	return nil
 }
@@ -208,6 +294,14 @@ func (c *command) executeCallback() {
 	}
 }
 
+func (c *command) executeHandler(ctx context.Context) error {
+	if c.handler != nil {
+		return c.handler(ctx, c)
+	}
+
+	return nil
+}
+
 func (c *command) appendPassthrough(val string) {
 	c.passthrough = append(c.passthrough, val)
 }
@@ -219,3 +313,7 @@ func (c command) Warnings() []string {
 func (c command) AppendWarning(warning string) {
 	c.warnings.appendWarning(warning)
 }
+
+func (c command) appendWarningError(err error) {
+	c.warnings.appendWarningError(err)
+}
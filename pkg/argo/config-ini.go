@@ -0,0 +1,74 @@
+package argo
+
+import (
+	"errors"
+	"strings"
+)
+
+// parseINIConfig parses the given INI configuration file contents.
+//
+// The supported syntax is a TOML-like subset of INI:
+//
+//     # comment
+//     ; comment
+//     key = value
+//     quoted = "a value"
+//     list = [a, "b", 'c']
+//
+//     [subcommand.leaf]
+//     key = value
+//
+// Keys and section names may contain dots to reference nested sections.
+func parseINIConfig(raw string) (*configSection, error) {
+	root := newConfigSection()
+	current := root
+
+	for i, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+
+		if len(line) == 0 || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return nil, newConfigFileError("", i+1, "", errors.New("unterminated section header"))
+			}
+
+			current = root
+			for _, name := range strings.Split(line[1:end], ".") {
+				if name = strings.TrimSpace(name); len(name) == 0 {
+					return nil, newConfigFileError("", i+1, "", errors.New("blank section name"))
+				}
+				current = current.child(name)
+			}
+
+			continue
+		}
+
+		idx := strings.IndexAny(line, "=:")
+		if idx < 1 {
+			return nil, newConfigFileError("", i+1, "", errors.New("expected a key and value separated by '='"))
+		}
+
+		key := strings.TrimSpace(line[:idx])
+		section := current
+
+		if dot := strings.LastIndexByte(key, '.'); dot > -1 {
+			for _, name := range strings.Split(key[:dot], ".") {
+				section = section.child(strings.TrimSpace(name))
+			}
+			key = strings.TrimSpace(key[dot+1:])
+		}
+
+		values, err := parseConfigValue(line[idx+1:])
+		if err != nil {
+			return nil, newConfigFileError("", i+1, key, err)
+		}
+
+		section.values[key] = values
+	}
+
+	return root, nil
+}
@@ -0,0 +1,78 @@
+package argo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSONConfig parses the given JSON configuration file contents.
+//
+// The root value must be an object.  Nested objects become nested sections,
+// arrays of scalar values produce multiple values, and null values are
+// ignored.
+func parseJSONConfig(raw string) (*configSection, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	root := newConfigSection()
+
+	if err := fillJSONConfigSection(doc, root); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+func fillJSONConfigSection(obj map[string]any, section *configSection) error {
+	for key, value := range obj {
+		switch v := value.(type) {
+		case nil:
+			continue
+
+		case map[string]any:
+			if err := fillJSONConfigSection(v, section.child(key)); err != nil {
+				return err
+			}
+
+		case []any:
+			values := make([]string, 0, len(v))
+
+			for _, item := range v {
+				if str, ok := jsonConfigScalar(item); ok {
+					values = append(values, str)
+				} else {
+					return newConfigFileError("", 0, key, fmt.Errorf("arrays may only contain scalar values"))
+				}
+			}
+
+			section.values[key] = values
+
+		default:
+			if str, ok := jsonConfigScalar(v); ok {
+				section.values[key] = []string{str}
+			}
+		}
+	}
+
+	return nil
+}
+
+func jsonConfigScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,140 @@
+package argo
+
+import (
+	"errors"
+	"strings"
+)
+
+// parseYAMLConfig parses the given YAML configuration file contents.
+//
+// Only a subset of YAML is supported: nested mappings of keys to scalar
+// values, block lists of scalar values, inline lists of scalar values, and
+// comments.
+//
+//     # comment
+//     key: value
+//     list: [a, b]
+//     other-list:
+//       - a
+//       - b
+//     subcommand:
+//       key: value
+func parseYAMLConfig(raw string) (*configSection, error) {
+	type frame struct {
+		indent  int
+		parent  *configSection
+		key     string
+		section *configSection
+		list    bool
+	}
+
+	root := newConfigSection()
+	stack := []*frame{{indent: -1, section: root}}
+
+	for i, line := range strings.Split(raw, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+
+		if len(content) == 0 || content[0] == '#' || content == "---" {
+			continue
+		}
+
+		if content[0] == '\t' {
+			return nil, newConfigFileError("", i+1, "", errors.New("tabs may not be used for indentation"))
+		}
+
+		isItem := content == "-" || strings.HasPrefix(content, "- ")
+
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+
+			// Block list items may be at the same indentation as their key.
+			if indent > top.indent || (isItem && indent == top.indent && top.section == nil) {
+				break
+			}
+
+			stack = stack[:len(stack)-1]
+		}
+
+		top := stack[len(stack)-1]
+
+		if isItem {
+			if top.section != nil || top.parent == nil {
+				return nil, newConfigFileError("", i+1, "", errors.New("unexpected list item"))
+			}
+
+			value, err := parseConfigValue(strings.TrimPrefix(content[1:], " "))
+			if err != nil {
+				return nil, newConfigFileError("", i+1, top.key, err)
+			}
+
+			top.list = true
+			top.parent.values[top.key] = append(top.parent.values[top.key], value...)
+			continue
+		}
+
+		if top.list {
+			return nil, newConfigFileError("", i+1, "", errors.New("unexpected mapping in list"))
+		}
+
+		if top.section == nil {
+			top.section = top.parent.child(top.key)
+		}
+
+		key, value, err := splitYAMLMapping(content)
+		if err != nil {
+			return nil, newConfigFileError("", i+1, "", err)
+		}
+
+		if len(value) == 0 {
+			stack = append(stack, &frame{indent: indent, parent: top.section, key: key})
+			continue
+		}
+
+		if value[0] == '{' || value[0] == '|' || value[0] == '>' || value[0] == '&' || value[0] == '*' {
+			return nil, newConfigFileError("", i+1, key, errors.New("unsupported YAML syntax"))
+		}
+
+		values, err := parseConfigValue(value)
+		if err != nil {
+			return nil, newConfigFileError("", i+1, key, err)
+		}
+
+		top.section.values[key] = values
+	}
+
+	return root, nil
+}
+
+// splitYAMLMapping splits the given YAML mapping line into its key and value.
+func splitYAMLMapping(line string) (key, value string, err error) {
+	var idx int
+
+	if line[0] == '"' || line[0] == '\'' {
+		end := strings.IndexByte(line[1:], line[0])
+		if end < 0 {
+			return "", "", errors.New("unterminated quoted key")
+		}
+
+		idx = end + 2
+		if idx >= len(line) || line[idx] != ':' {
+			return "", "", errors.New("expected ':' after mapping key")
+		}
+
+		key = line[1 : end+1]
+	} else {
+		if idx = strings.Index(line, ": "); idx < 0 {
+			if !strings.HasSuffix(line, ":") {
+				return "", "", errors.New("expected a key and value separated by ':'")
+			}
+			idx = len(line) - 1
+		}
+
+		key = strings.TrimSpace(line[:idx])
+	}
+
+	value = stripConfigComment(strings.TrimSpace(line[idx+1:]))
+
+	return key, value, nil
+}
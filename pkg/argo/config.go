@@ -0,0 +1,319 @@
+package argo
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	configFlagDescription = "Path to a configuration file to load flag values from."
+	configFlagArgName     = "path"
+)
+
+// configLoader holds the configuration file settings for a Command or
+// CommandTree, and is used to locate and load the configuration file after the
+// CLI call has been parsed.
+type configLoader struct {
+	// path is the default configuration file path.
+	path string
+
+	// flag is the long-form name of the flag that may be used to provide a
+	// configuration file path on the CLI.
+	flag string
+}
+
+// load reads and parses the configuration file for a CLI call, if one is
+// available.
+//
+// A path given by the configuration flag takes priority over the default path.
+// If the default path does not exist, it is ignored, however, if a path given
+// by the configuration flag does not exist, an error is returned.
+func (l *configLoader) load(finder flagFinder) (*configFile, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	path := l.path
+	optional := true
+
+	if len(l.flag) > 0 {
+		if flag := finder.findExactLongFlag(l.flag); flag != nil && flag.WasHit() && flag.Argument().WasHit() {
+			path = flag.Argument().RawValue()
+			optional = false
+		}
+	}
+
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, newConfigFileError(path, 0, "", err)
+	}
+
+	return parseConfigFile(path, string(raw))
+}
+
+func (l *configLoader) makeFlag() FlagBuilder {
+	return NewFlagBuilder().
+		WithLongForm(l.flag).
+		WithDescription(configFlagDescription).
+		WithArgument(NewArgumentBuilder().
+			WithName(configFlagArgName).
+			Require())
+}
+
+// parseConfigFile parses the given configuration file contents based on the
+// extension of the given file path.
+//
+// Files ending in ".json" are parsed as JSON, files ending in ".yaml" or ".yml"
+// are parsed as YAML, and all other files are parsed as INI.
+func parseConfigFile(path, raw string) (*configFile, error) {
+	var root *configSection
+	var err error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		root, err = parseJSONConfig(raw)
+	case ".yaml", ".yml":
+		root, err = parseYAMLConfig(raw)
+	default:
+		root, err = parseINIConfig(raw)
+	}
+
+	if err != nil {
+		var cfe ConfigFileError
+		if errors.As(err, &cfe) {
+			return nil, newConfigFileError(path, cfe.Line(), cfe.Key(), cfe.Unwrap())
+		}
+
+		return nil, newConfigFileError(path, 0, "", err)
+	}
+
+	return &configFile{path, root}, nil
+}
+
+// configFile is a parsed configuration file.
+type configFile struct {
+	path string
+	root *configSection
+}
+
+// applyFlag attempts to set the value of the given flag's argument from the
+// configuration file.
+//
+// The given command path is the list of subcommand names leading to the node
+// the flag belongs to, not including the name of the root command.
+//
+// Returns a flag indicating whether a value for the flag was present in the
+// configuration file and used, and any error encountered while setting the
+// value.
+func (c *configFile) applyFlag(path []string, flag Flag) (bool, error) {
+	if c == nil || !flag.HasLongForm() || !flag.HasArgument() {
+		return false, nil
+	}
+
+	values, ok := c.root.lookup(path, flag.LongForm())
+	if !ok {
+		return false, nil
+	}
+
+	key := strings.Join(append(path[:len(path):len(path)], flag.LongForm()), ".")
+
+	// Values for flags that take multiple arguments are assigned to those
+	// arguments in order.
+	args := flag.Arguments()
+
+	for i, value := range values {
+		if err := args[i%len(args)].setValue(value); err != nil {
+			return true, newConfigFileError(c.path, 0, key, err)
+		}
+	}
+
+	return true, nil
+}
+
+// configSection is a single table of keys and values in a configuration file,
+// which may contain nested sections for subcommands.
+type configSection struct {
+	values   map[string][]string
+	sections map[string]*configSection
+}
+
+func newConfigSection() *configSection {
+	return &configSection{
+		values:   make(map[string][]string, 8),
+		sections: make(map[string]*configSection, 2),
+	}
+}
+
+// child returns the nested section with the given name, creating it if it does
+// not already exist.
+func (c *configSection) child(name string) *configSection {
+	if out, ok := c.sections[name]; ok {
+		return out
+	}
+
+	out := newConfigSection()
+	c.sections[name] = out
+	return out
+}
+
+// lookup returns the values for the given key in the section at the given
+// path.
+//
+// If the key refers to a nested section rather than a value, the section's
+// values are returned as "key=value" pairs to allow populating map bindings.
+func (c *configSection) lookup(path []string, key string) ([]string, bool) {
+	current := c
+
+	for _, name := range path {
+		if next, ok := current.sections[name]; ok {
+			current = next
+		} else {
+			return nil, false
+		}
+	}
+
+	if values, ok := current.values[key]; ok {
+		return values, true
+	}
+
+	if section, ok := current.sections[key]; ok {
+		out := make([]string, 0, len(section.values))
+
+		for name, values := range section.values {
+			for _, value := range values {
+				out = append(out, name+"="+value)
+			}
+		}
+
+		return out, true
+	}
+
+	return nil, false
+}
+
+// parseConfigValue parses the given raw value from an INI or YAML
+// configuration file into one or more string values.
+//
+// Inline lists in the form `[a, b, c]` produce multiple values.  Quoted values
+// are unquoted, and unquoted values may be followed by a comment.
+func parseConfigValue(raw string) ([]string, error) {
+	raw = stripConfigComment(strings.TrimSpace(raw))
+
+	if len(raw) > 1 && raw[0] == '[' && raw[len(raw)-1] == ']' {
+		items, err := splitConfigList(raw[1 : len(raw)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			value, err := unquoteConfigValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, value)
+		}
+
+		return out, nil
+	}
+
+	value, err := unquoteConfigValue(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{value}, nil
+}
+
+// stripConfigComment removes a trailing comment from the given value.
+//
+// Comments start with a '#' character that is preceded by whitespace and is
+// not contained in a quoted string.
+func stripConfigComment(raw string) string {
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case quote != 0:
+			if raw[i] == '\\' && quote == '"' {
+				i++
+			} else if raw[i] == quote {
+				quote = 0
+			}
+		case raw[i] == '"' || raw[i] == '\'':
+			quote = raw[i]
+		case raw[i] == '#' && i > 0 && (raw[i-1] == ' ' || raw[i-1] == '\t'):
+			return strings.TrimSpace(raw[:i])
+		}
+	}
+
+	return raw
+}
+
+// splitConfigList splits the contents of an inline list on commas that are not
+// contained in quoted strings.
+func splitConfigList(raw string) ([]string, error) {
+	out := make([]string, 0, 4)
+	start := 0
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case quote != 0:
+			if raw[i] == '\\' && quote == '"' {
+				i++
+			} else if raw[i] == quote {
+				quote = 0
+			}
+		case raw[i] == '"' || raw[i] == '\'':
+			quote = raw[i]
+		case raw[i] == ',':
+			out = append(out, strings.TrimSpace(raw[start:i]))
+			start = i + 1
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quoted string")
+	}
+
+	if last := strings.TrimSpace(raw[start:]); len(last) > 0 || len(out) > 0 {
+		out = append(out, last)
+	}
+
+	return out, nil
+}
+
+// unquoteConfigValue removes the quotes from the given value if it is quoted.
+//
+// Double-quoted values may contain Go style escape sequences, single-quoted
+// values are taken literally.
+func unquoteConfigValue(raw string) (string, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+
+	switch raw[0] {
+	case '"':
+		return strconv.Unquote(raw)
+	case '\'':
+		if len(raw) < 2 || raw[len(raw)-1] != '\'' {
+			return "", errors.New("unterminated quoted string")
+		}
+		return raw[1 : len(raw)-1], nil
+	default:
+		return raw, nil
+	}
+}
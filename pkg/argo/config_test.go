@@ -0,0 +1,293 @@
+package argo_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cli "github.com/Foxcapades/Argonaut"
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// JSON config values are unmarshaled into their bindings
+func TestConfigFile_json(t *testing.T) {
+	var timeout time.Duration
+	var labels map[string]string
+	var names []string
+	var verbose bool
+
+	path := writeConfigFile(t, "config.json", `{
+  "timeout": "30s",
+  "labels": {"a": "1", "b": "2"},
+  "name": ["foo", "bar"],
+  "verbose": true
+}`)
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("timeout").WithBinding(&timeout, true)).
+		WithFlag(cli.LongFlag("labels").WithBinding(&labels, true)).
+		WithFlag(cli.LongFlag("name").WithBinding(&names, true)).
+		WithFlag(cli.LongFlag("verbose").WithBinding(&verbose, false)).
+		WithConfigFile(path).
+		Parse([]string{"command"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if timeout != 30*time.Second {
+		t.Error("expected timeout to be 30s but was", timeout)
+	}
+
+	if len(labels) != 2 || labels["a"] != "1" || labels["b"] != "2" {
+		t.Error("expected labels to be map[a:1 b:2] but was", labels)
+	}
+
+	if len(names) != 2 || names[0] != "foo" || names[1] != "bar" {
+		t.Error("expected names to be [foo bar] but was", names)
+	}
+
+	if !verbose {
+		t.Error("expected verbose to be true but it wasn't")
+	}
+}
+
+// Precedence is CLI, then env, then config, then default
+func TestConfigFile_precedence(t *testing.T) {
+	var a, b, c, d int
+
+	t.Setenv("ARGO_TEST_B", "2")
+
+	path := writeConfigFile(t, "config.ini", `
+# comment
+a = 10
+b = 20
+c = 30
+`)
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("a").WithBindingAndDefault(&a, 100, false)).
+		WithFlag(cli.LongFlag("b").WithBindingAndDefault(&b, 200, false).WithEnvVar("ARGO_TEST_B")).
+		WithFlag(cli.LongFlag("c").WithBindingAndDefault(&c, 300, false)).
+		WithFlag(cli.LongFlag("d").WithBindingAndDefault(&d, 400, false)).
+		WithConfigFile(path).
+		Parse([]string{"command", "--a=1"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if a != 1 || b != 2 || c != 30 || d != 400 {
+		t.Errorf("expected values to be 1, 2, 30, 400 but were %d, %d, %d, %d", a, b, c, d)
+	}
+}
+
+// Config flag overrides the default config file path
+func TestConfigFile_flag(t *testing.T) {
+	var value string
+
+	path := writeConfigFile(t, "config.yaml", "value: from-flag\n")
+
+	com, err := cli.Command().
+		WithFlag(cli.LongFlag("value").WithBinding(&value, true)).
+		WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")).
+		WithConfigFlag("config").
+		Parse([]string{"command", "--config", path})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if value != "from-flag" {
+		t.Error("expected value to be from-flag but was", value)
+	}
+
+	if com.FindLongFlag("config") == nil {
+		t.Error("expected config flag to exist but it didn't")
+	}
+}
+
+// Config flag path that does not exist is an error
+func TestConfigFile_flagMissing(t *testing.T) {
+	_, err := cli.Command().
+		WithConfigFlag("config").
+		Parse([]string{"command", "--config", filepath.Join(t.TempDir(), "missing.json")})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.ConfigFileError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Error("expected err to be a ConfigFileError but was", err)
+	}
+}
+
+// Tree flags are keyed by subcommand path
+func TestConfigFile_tree(t *testing.T) {
+	var root, leaf string
+	var ports []int
+
+	path := writeConfigFile(t, "config.yaml", `
+root: top # comment
+branch:
+  leaf:
+    name: "nested value"
+    port:
+    - 80
+    - 443
+`)
+
+	_, err := cli.Tree().
+		WithFlag(cli.LongFlag("root").WithBinding(&root, true)).
+		WithBranch(cli.Branch("branch").
+			WithLeaf(cli.Leaf("leaf").
+				WithFlag(cli.LongFlag("name").WithBinding(&leaf, true)).
+				WithFlag(cli.LongFlag("port").WithBinding(&ports, true)))).
+		WithConfigFile(path).
+		Parse([]string{"command", "branch", "leaf"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if root != "top" {
+		t.Error("expected root to be top but was", root)
+	}
+
+	if leaf != "nested value" {
+		t.Error("expected leaf to be nested value but was", leaf)
+	}
+
+	if len(ports) != 2 || ports[0] != 80 || ports[1] != 443 {
+		t.Error("expected ports to be [80 443] but was", ports)
+	}
+}
+
+// INI sections are keyed by subcommand path
+func TestConfigFile_treeINI(t *testing.T) {
+	var value string
+
+	path := writeConfigFile(t, "config.toml", `
+[branch.leaf]
+value = "hello world"
+`)
+
+	_, err := cli.Tree().
+		WithBranch(cli.Branch("branch").
+			WithLeaf(cli.Leaf("leaf").
+				WithFlag(cli.LongFlag("value").WithBinding(&value, true)))).
+		WithConfigFile(path).
+		Parse([]string{"command", "branch", "leaf"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if value != "hello world" {
+		t.Error("expected value to be hello world but was", value)
+	}
+}
+
+// Config flag on a tree is registered on the root and loads the given file
+func TestConfigFile_treeFlag(t *testing.T) {
+	var value string
+
+	path := writeConfigFile(t, "cfg.yaml", "svc:\n  start:\n    value: from-flag\n")
+
+	tree, err := cli.Tree().
+		WithBranch(cli.Branch("svc").
+			WithLeaf(cli.Leaf("start").
+				WithFlag(cli.LongFlag("value").WithBinding(&value, true)))).
+		WithConfigFlag("config").
+		Parse([]string{"command", "--config", path, "svc", "start"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if value != "from-flag" {
+		t.Error("expected value to be from-flag but was", value)
+	}
+
+	if tree.FindLongFlag("config") == nil {
+		t.Error("expected config flag to exist but it didn't")
+	}
+}
+
+// Config flag path on a tree that does not exist is an error
+func TestConfigFile_treeFlagMissing(t *testing.T) {
+	_, err := cli.Tree().
+		WithLeaf(cli.Leaf("start")).
+		WithConfigFlag("config").
+		Parse([]string{"command", "--config=" + filepath.Join(t.TempDir(), "missing.yaml"), "start"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.ConfigFileError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Error("expected err to be a ConfigFileError but was", err)
+	}
+}
+
+// Invalid config values are reported with their key
+func TestConfigFile_invalidValue(t *testing.T) {
+	var value int
+
+	path := writeConfigFile(t, "config.ini", "value = nope\n")
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("value").WithBinding(&value, true)).
+		WithConfigFile(path).
+		Parse([]string{"command"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.ConfigFileError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be a ConfigFileError but was", err)
+	}
+
+	if ce.Key() != "value" {
+		t.Error("expected key to be value but was", ce.Key())
+	}
+}
+
+// Malformed config files are reported with their line number
+func TestConfigFile_syntaxError(t *testing.T) {
+	path := writeConfigFile(t, "config.ini", "a = 1\nnot a pair\n")
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("a")).
+		WithConfigFile(path).
+		Parse([]string{"command"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.ConfigFileError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be a ConfigFileError but was", err)
+	}
+
+	if ce.Line() != 2 {
+		t.Error("expected line to be 2 but was", ce.Line())
+	}
+}
@@ -0,0 +1,110 @@
+package argo
+
+import (
+	"fmt"
+)
+
+// deprecationWarning returns the warning recorded when a deprecated flag or
+// subcommand is used.
+//
+// The given subject describes the deprecated flag or subcommand, for example
+// "flag --colour".
+func deprecationWarning(subject, message, replacement string) string {
+	warning := subject + " is deprecated"
+
+	if len(message) > 0 {
+		return warning + ": " + message
+	}
+
+	if len(replacement) > 0 {
+		return warning + "; use " + replacement + " instead"
+	}
+
+	return warning
+}
+
+// flagDeprecationWarning returns the warning recorded when the given deprecated
+// flag is used.
+func flagDeprecationWarning(flag Flag) string {
+	return deprecationWarning("flag "+printFlagNames(flag), flag.DeprecationMessage(), flag.Replacement())
+}
+
+// childDeprecationWarning returns the warning recorded when the given
+// deprecated subcommand is used.
+func childDeprecationWarning(child CommandChild) string {
+	return deprecationWarning(fmt.Sprintf("subcommand \"%s\"", child.Name()), child.DeprecationMessage(), child.Replacement())
+}
+
+// argumentDeprecationWarning returns the warning recorded when a value is passed
+// to the given deprecated positional argument at the given index.
+func argumentDeprecationWarning(arg Argument, index int) string {
+	if arg.HasName() {
+		return deprecationWarning(fmt.Sprintf("argument \"%s\"", arg.Name()), arg.DeprecationMessage(), "")
+	}
+
+	return deprecationWarning(fmt.Sprintf("argument %d", index+1), arg.DeprecationMessage(), "")
+}
+
+// useArgument records a warning if the given positional argument at the given
+// index is deprecated and is receiving its first value.
+func useArgument(arg Argument, index int) {
+	if arg.IsDeprecated() && !arg.WasHit() {
+		arg.AppendWarning(argumentDeprecationWarning(arg, index))
+	}
+}
+
+// replacementFlag returns the flag that should be used in place of the given
+// flag.
+//
+// If the given flag has no replacement, or if the flag was negated and its
+// replacement is not negatable, it is returned as is.
+func replacementFlag(finder flagFinder, flag Flag, negated bool) Flag {
+	if flag.HasReplacement() {
+		if target := findFlagByRef(finder, flag.Replacement()); target != nil && (!negated || target.IsNegatable()) {
+			return target
+		}
+	}
+
+	return flag
+}
+
+// replacementChild returns the subcommand that should be used in place of the
+// given child of the given parent.
+//
+// If the given child has no replacement, it is returned as is.
+func replacementChild(parent CommandParent, child CommandChild) CommandChild {
+	if child.HasReplacement() {
+		if target, _ := lookupChild(parent.CommandGroups(), child.Replacement(), false); target != nil {
+			return target
+		}
+	}
+
+	return child
+}
+
+// validateTreeReplacements ensures that every subcommand replacement named by
+// the descendants of the given node refers to a sibling of that descendant.
+func validateTreeReplacements(node CommandParent, errs MultiError) {
+	for _, group := range node.CommandGroups() {
+		for _, branch := range group.Branches() {
+			validateChildReplacement(node, branch, errs)
+			validateTreeReplacements(branch, errs)
+		}
+
+		for _, leaf := range group.Leaves() {
+			validateChildReplacement(node, leaf, errs)
+		}
+	}
+}
+
+func validateChildReplacement(parent CommandParent, child CommandChild, errs MultiError) {
+	if !child.HasReplacement() {
+		return
+	}
+
+	if target, _ := lookupChild(parent.CommandGroups(), child.Replacement(), false); target == nil {
+		errs.AppendError(fmt.Errorf("subcommand \"%s\" is replaced by unknown subcommand \"%s\"", child.Name(), child.Replacement()))
+	} else if target.Name() == child.Name() {
+		errs.AppendError(fmt.Errorf("subcommand \"%s\" is replaced by itself", child.Name()))
+	}
+}
@@ -90,3 +90,78 @@ func (m *missingArgError) Error() string {
 func (m *missingArgError) Argument() Argument {
 	return m.arg
 }
+
+// ////////////////////////////////////////////////////////////////////////// //
+//                                                                            //
+//    Argument Count Error                                                    //
+//                                                                            //
+// ////////////////////////////////////////////////////////////////////////// //
+
+// An ArgumentCountError is returned on CLI parse when a variadic argument was
+// passed fewer values than its configured minimum count.
+type ArgumentCountError interface {
+	error
+
+	// Argument returns the variadic argument that was passed too few values.
+	Argument() Argument
+
+	// Count returns the number of values that were passed to the argument.
+	Count() int
+}
+
+func newArgumentCountError(arg Argument) ArgumentCountError {
+	return &argumentCountError{arg}
+}
+
+type argumentCountError struct {
+	arg Argument
+}
+
+func (a *argumentCountError) Argument() Argument {
+	return a.arg
+}
+
+func (a *argumentCountError) Count() int {
+	return len(a.arg.RawValues())
+}
+
+func (a *argumentCountError) Error() string {
+	return fmt.Sprintf(
+		"argument <%s> requires at least %d values but %d were given",
+		renderArgName(a.arg, 0),
+		a.arg.MinCount(),
+		a.Count(),
+	)
+}
+
+// ////////////////////////////////////////////////////////////////////////// //
+//                                                                            //
+//    Too Many Arguments Error                                                //
+//                                                                            //
+// ////////////////////////////////////////////////////////////////////////// //
+
+// A TooManyArgumentsError is returned on CLI parse in strict mode when a
+// command is passed a positional argument that is not accepted by any of its
+// arguments, and the command does not collect unmapped inputs.
+type TooManyArgumentsError interface {
+	error
+
+	// Value returns the positional argument that was not accepted.
+	Value() string
+}
+
+func newTooManyArgumentsError(value string) TooManyArgumentsError {
+	return &tooManyArgumentsError{value}
+}
+
+type tooManyArgumentsError struct {
+	value string
+}
+
+func (t *tooManyArgumentsError) Value() string {
+	return t.value
+}
+
+func (t *tooManyArgumentsError) Error() string {
+	return fmt.Sprintf("unexpected positional argument \"%s\"", t.value)
+}
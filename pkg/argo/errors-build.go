@@ -8,6 +8,8 @@ import (
 
 // A MultiError is an error instance that contains a collection of sub-errors
 // of any type except MultiError.
+//
+// The sub-errors of a MultiError may be tested with errors.Is and errors.As.
 type MultiError interface {
 	error
 
@@ -15,6 +17,9 @@ type MultiError interface {
 	// instance.
 	Errors() []error
 
+	// Unwrap returns the errors collected into this MultiError instance.
+	Unwrap() []error
+
 	// AppendError appends the given error to this MultiError.  If the given error
 	// is itself a MultiError, it will be unpacked into this MultiError instance.
 	AppendError(err error)
@@ -49,6 +54,10 @@ func (m *multiError) Errors() []error {
 	return m.errs
 }
 
+func (m *multiError) Unwrap() []error {
+	return m.errs
+}
+
 func (m *multiError) AppendError(err error) {
 	var e MultiError
 	if errors.As(err, &e) {
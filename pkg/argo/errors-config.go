@@ -0,0 +1,78 @@
+package argo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A ConfigFileError is returned on CLI parse when a configuration file could
+// not be read or parsed, or when a value from a configuration file could not be
+// applied to its flag.
+type ConfigFileError interface {
+	error
+
+	// Path returns the path to the configuration file.
+	Path() string
+
+	// Line returns the line number in the configuration file at which the error
+	// occurred.
+	//
+	// If the error is not associated with a specific line, this method will
+	// return 0.
+	Line() int
+
+	// Key returns the dot separated key of the value that caused the error.
+	//
+	// If the error is not associated with a specific key, this method will return
+	// an empty string.
+	Key() string
+
+	// Unwrap returns the underlying error.
+	Unwrap() error
+}
+
+func newConfigFileError(path string, line int, key string, root error) ConfigFileError {
+	return &configFileError{path, line, key, root}
+}
+
+type configFileError struct {
+	path string
+	line int
+	key  string
+	root error
+}
+
+func (c *configFileError) Path() string {
+	return c.path
+}
+
+func (c *configFileError) Line() int {
+	return c.line
+}
+
+func (c *configFileError) Key() string {
+	return c.key
+}
+
+func (c *configFileError) Unwrap() error {
+	return c.root
+}
+
+func (c *configFileError) Error() string {
+	sb := strings.Builder{}
+	sb.WriteString("config file ")
+	sb.WriteString(c.path)
+
+	if c.line > 0 {
+		sb.WriteString(fmt.Sprintf(" line %d", c.line))
+	}
+
+	if len(c.key) > 0 {
+		sb.WriteString(fmt.Sprintf(" key %s", c.key))
+	}
+
+	sb.WriteString(": ")
+	sb.WriteString(c.root.Error())
+
+	return sb.String()
+}
@@ -80,3 +80,123 @@ func (m missingFlagError) Error() string {
 func (m missingFlagError) Flag() Flag {
 	return m.flag
 }
+
+// ////////////////////////////////////////////////////////////////////////// //
+//                                                                            //
+//    Flag Constraint Error                                                   //
+//                                                                            //
+// ////////////////////////////////////////////////////////////////////////// //
+
+// FlagConstraintKind identifies the type of flag constraint that was violated.
+type FlagConstraintKind uint8
+
+const (
+	// FlagConstraintMutuallyExclusive indicates that more than one flag from a
+	// mutually exclusive flag group was used.
+	FlagConstraintMutuallyExclusive FlagConstraintKind = iota
+
+	// FlagConstraintExactlyOne indicates that either none or more than one flag
+	// from a flag group requiring exactly one flag was used.
+	FlagConstraintExactlyOne
+
+	// FlagConstraintRequires indicates that a flag was used without a flag that
+	// it requires.
+	FlagConstraintRequires
+
+	// FlagConstraintConflicts indicates that a flag was used alongside a flag
+	// that it conflicts with.
+	FlagConstraintConflicts
+)
+
+// A FlagConstraintError is returned on CLI parse when a constraint declared on
+// a flag or flag group was violated by the CLI call.
+type FlagConstraintError interface {
+	error
+
+	// Kind returns the type of the constraint that was violated.
+	Kind() FlagConstraintKind
+
+	// Flags returns the flags involved in the constraint violation.
+	//
+	// For FlagConstraintMutuallyExclusive errors, and FlagConstraintExactlyOne
+	// errors where more than one flag was used, these are the flags that were
+	// used.  For FlagConstraintExactlyOne errors where no flags were used, these
+	// are all the flags in the group.  For FlagConstraintRequires and
+	// FlagConstraintConflicts errors, these are the flag that declared the
+	// constraint followed by the flag it references.
+	Flags() []Flag
+}
+
+func newFlagConstraintError(kind FlagConstraintKind, flags []Flag) FlagConstraintError {
+	return flagConstraintError{kind, flags}
+}
+
+type flagConstraintError struct {
+	kind  FlagConstraintKind
+	flags []Flag
+}
+
+func (f flagConstraintError) Error() string {
+	switch f.kind {
+	case FlagConstraintMutuallyExclusive:
+		return fmt.Sprintf("flags %s may not be used together", printFlagList(f.flags))
+	case FlagConstraintExactlyOne:
+		if len(f.flags) > 0 && f.flags[0].WasHit() {
+			return fmt.Sprintf("only one of the flags %s may be used", printFlagList(f.flags))
+		}
+		return fmt.Sprintf("exactly one of the flags %s must be used", printFlagList(f.flags))
+	case FlagConstraintRequires:
+		return fmt.Sprintf("flag %s requires flag %s", printFlagNames(f.flags[0]), printFlagNames(f.flags[1]))
+	case FlagConstraintConflicts:
+		return fmt.Sprintf("flag %s may not be used with flag %s", printFlagNames(f.flags[0]), printFlagNames(f.flags[1]))
+	default:
+		panic("illegal state: unrecognized flag constraint kind")
+	}
+}
+
+func (f flagConstraintError) Kind() FlagConstraintKind {
+	return f.kind
+}
+
+func (f flagConstraintError) Flags() []Flag {
+	return f.flags
+}
+
+// ////////////////////////////////////////////////////////////////////////// //
+//                                                                            //
+//    Flag Arity Error                                                        //
+//                                                                            //
+// ////////////////////////////////////////////////////////////////////////// //
+
+// A FlagArityError is returned on CLI parse when a flag that takes multiple
+// arguments was not followed by enough values to fill all of its arguments.
+type FlagArityError interface {
+	error
+
+	// Flag returns the flag that did not receive enough values.
+	Flag() Flag
+
+	// Received returns the number of values the flag received.
+	Received() int
+}
+
+func newFlagArityError(flag Flag, received int) FlagArityError {
+	return flagArityError{flag, received}
+}
+
+type flagArityError struct {
+	flag     Flag
+	received int
+}
+
+func (f flagArityError) Error() string {
+	return fmt.Sprintf("flag %s requires %d inputs but received %d", printFlagNames(f.flag), len(f.flag.Arguments()), f.received)
+}
+
+func (f flagArityError) Flag() Flag {
+	return f.flag
+}
+
+func (f flagArityError) Received() int {
+	return f.received
+}
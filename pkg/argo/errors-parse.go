@@ -0,0 +1,374 @@
+package argo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+)
+
+// ┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓ //
+// ┃     Ambiguous Prefix        ┃ //
+// ┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛ //
+
+// An AmbiguousPrefixError is returned on CLI parse when abbreviations are
+// enabled and an abbreviated long flag or subcommand name matches more than one
+// flag or subcommand.
+type AmbiguousPrefixError interface {
+	error
+
+	// Prefix returns the abbreviated input, without any leading dashes.
+	Prefix() string
+
+	// Candidates returns the flags or subcommands that the abbreviated input
+	// matched.
+	//
+	// Flags are returned in their CLI form, for example "--verbose", and
+	// subcommands are returned by name.
+	Candidates() []string
+
+	// IsFlag indicates whether the abbreviated input was a long flag rather than
+	// a subcommand name.
+	IsFlag() bool
+}
+
+func newAmbiguousPrefixError(prefix string, candidates []string, isFlag bool) AmbiguousPrefixError {
+	return ambiguousPrefixError{prefix, candidates, isFlag}
+}
+
+type ambiguousPrefixError struct {
+	prefix     string
+	candidates []string
+	isFlag     bool
+}
+
+func (a ambiguousPrefixError) Error() string {
+	if a.isFlag {
+		return fmt.Sprintf("ambiguous flag --%s could match %s", a.prefix, strings.Join(a.candidates, ", "))
+	}
+
+	return fmt.Sprintf("ambiguous subcommand \"%s\" could match %s", a.prefix, strings.Join(a.candidates, ", "))
+}
+
+func (a ambiguousPrefixError) Prefix() string {
+	return a.prefix
+}
+
+func (a ambiguousPrefixError) Candidates() []string {
+	return a.candidates
+}
+
+func (a ambiguousPrefixError) IsFlag() bool {
+	return a.isFlag
+}
+
+// ┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓ //
+// ┃     Response File           ┃ //
+// ┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛ //
+
+// A ResponseFileError is returned on CLI parse when response files are enabled
+// and a response file could not be read or tokenized, or when response files
+// reference each other in a cycle.
+type ResponseFileError interface {
+	error
+
+	// Path returns the path to the response file, as it was referenced.
+	Path() string
+
+	// Unwrap returns the underlying error.
+	Unwrap() error
+}
+
+func newResponseFileError(path string, root error) ResponseFileError {
+	return responseFileError{path, root}
+}
+
+type responseFileError struct {
+	path string
+	root error
+}
+
+func (r responseFileError) Error() string {
+	return "response file " + r.path + ": " + r.root.Error()
+}
+
+func (r responseFileError) Path() string {
+	return r.path
+}
+
+func (r responseFileError) Unwrap() error {
+	return r.root
+}
+
+// ┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓ //
+// ┃     Unknown Subcommand      ┃ //
+// ┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛ //
+
+// An UnknownSubcommandError is returned on CLI parse when a command tree or
+// branch is passed a subcommand name that does not match any of its
+// subcommands.
+type UnknownSubcommandError interface {
+	error
+
+	// Input returns the unrecognized subcommand name.
+	Input() string
+
+	// Suggestions returns the names and aliases of the subcommands that are
+	// similar to the unrecognized input, closest first.
+	Suggestions() []string
+}
+
+func newUnknownSubcommandError(input string, suggestions []string, message string) UnknownSubcommandError {
+	return unknownSubcommandError{input, suggestions, message}
+}
+
+type unknownSubcommandError struct {
+	input       string
+	suggestions []string
+	message     string
+}
+
+func (u unknownSubcommandError) Error() string {
+	return u.message
+}
+
+func (u unknownSubcommandError) Input() string {
+	return u.input
+}
+
+func (u unknownSubcommandError) Suggestions() []string {
+	return u.suggestions
+}
+
+// ┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓ //
+// ┃     Unknown Flag            ┃ //
+// ┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛ //
+
+// An UnknownFlagError describes a flag in a CLI call that does not match any
+// flag available to the command being called.
+//
+// Unknown flags are recorded as warnings on the WarningContext, and may be
+// retrieved with WarningContext.GetWarningErrors.
+type UnknownFlagError interface {
+	error
+
+	// Flag returns the unrecognized flag in its CLI form, for example "--verbos"
+	// or "-x".
+	Flag() string
+
+	// Suggestions returns the flags that are similar to the unrecognized flag,
+	// closest first, in their CLI form.
+	Suggestions() []string
+}
+
+func newUnknownFlagError(flag string, suggestions []string) UnknownFlagError {
+	return unknownFlagError{flag, suggestions}
+}
+
+type unknownFlagError struct {
+	flag        string
+	suggestions []string
+}
+
+func (u unknownFlagError) Error() string {
+	var msg string
+
+	if strings.HasPrefix(u.flag, chars.StrDoubleDash) {
+		msg = "unrecognized long flag " + u.flag
+	} else {
+		msg = "unrecognized short flag " + u.flag
+	}
+
+	switch len(u.suggestions) {
+	case 0:
+		return msg
+	case 1:
+		return msg + "; did you mean " + u.suggestions[0] + "?"
+	default:
+		return msg + "; did you mean one of " + strings.Join(u.suggestions, ", ") + "?"
+	}
+}
+
+func (u unknownFlagError) Flag() string {
+	return u.flag
+}
+
+func (u unknownFlagError) Suggestions() []string {
+	return u.suggestions
+}
+
+// ┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓ //
+// ┃     Parse Error             ┃ //
+// ┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛ //
+
+// ParseErrorCode is a stable identifier for the kind of problem described by a
+// ParseError.
+//
+// The string values of these codes will not change, and are safe to compare
+// against, log, or expose to scripts consuming the output of a CLI tool.
+type ParseErrorCode string
+
+const (
+	// ParseErrorUnknownSubcommand indicates that a command tree or branch was
+	// passed a subcommand name that does not match any of its subcommands.
+	ParseErrorUnknownSubcommand ParseErrorCode = "unknown-subcommand"
+
+	// ParseErrorUnknownFlag indicates that a flag was used that does not match
+	// any flag available to the command being called.
+	ParseErrorUnknownFlag ParseErrorCode = "unknown-flag"
+
+	// ParseErrorMissingFlagValue indicates that a flag that requires an argument
+	// was not given a value, or was given fewer values than it requires.
+	ParseErrorMissingFlagValue ParseErrorCode = "missing-flag-value"
+
+	// ParseErrorInvalidValue indicates that a value could not be parsed into, or
+	// was rejected by, the argument it was passed to.
+	ParseErrorInvalidValue ParseErrorCode = "invalid-value"
+
+	// ParseErrorMissingArgument indicates that a required positional argument was
+	// not given a value, or was given fewer values than its minimum count.
+	ParseErrorMissingArgument ParseErrorCode = "missing-argument"
+
+	// ParseErrorTooManyArguments indicates that a command was passed more
+	// positional arguments than it accepts.
+	ParseErrorTooManyArguments ParseErrorCode = "too-many-arguments"
+
+	// ParseErrorConstraintViolation indicates that a required flag was not used,
+	// or that the flags used violate a flag group or flag relationship
+	// constraint.
+	ParseErrorConstraintViolation ParseErrorCode = "constraint-violation"
+)
+
+// A ParseError is returned on CLI parse when the CLI inputs could not be
+// interpreted by the called command.
+//
+// A ParseError wraps the underlying error describing the problem, which may be
+// retrieved with errors.As.
+//
+// Example:
+//     var unknown argo.UnknownSubcommandError
+//     if errors.As(err, &unknown) {
+//         fmt.Println(unknown.Suggestions())
+//     }
+//
+// When more than one problem is encountered, the ParseError instances will be
+// collected into a MultiError.
+type ParseError interface {
+	error
+
+	// Code returns the stable code identifying the kind of problem this error
+	// describes.
+	Code() ParseErrorCode
+
+	// CommandPath returns the path to the command that was being interpreted
+	// when this error was encountered, starting with the program name and
+	// followed by the names of any subcommands.
+	CommandPath() []string
+
+	// Position returns the index of the CLI arg that caused this error, where the
+	// program name is at index 0.
+	//
+	// If this error was not caused by a specific CLI arg, for example a required
+	// flag that was never used, this method returns -1.
+	Position() int
+
+	// Token returns the CLI arg that caused this error.
+	//
+	// If this error was not caused by a specific CLI arg, this method returns an
+	// empty string.
+	Token() string
+
+	// Unwrap returns the underlying error.
+	Unwrap() error
+}
+
+func newParseError(code ParseErrorCode, path []string, position int, token string, root error) ParseError {
+	return parseError{code, path, position, token, root}
+}
+
+type parseError struct {
+	code     ParseErrorCode
+	path     []string
+	position int
+	token    string
+	root     error
+}
+
+func (p parseError) Error() string {
+	return p.root.Error()
+}
+
+func (p parseError) Code() ParseErrorCode {
+	return p.code
+}
+
+func (p parseError) CommandPath() []string {
+	return p.path
+}
+
+func (p parseError) Position() int {
+	return p.position
+}
+
+func (p parseError) Token() string {
+	return p.token
+}
+
+func (p parseError) Unwrap() error {
+	return p.root
+}
+
+// parseErrorCode returns the ParseErrorCode for the given error, and whether
+// the given error should be wrapped in a ParseError at all.
+//
+// Errors that are not caused by CLI inputs, such as config file errors and
+// exit errors, and errors that are already ParseError instances are not
+// wrapped.  Any other errors encountered while interpreting CLI inputs are the
+// result of setting argument values.
+func parseErrorCode(err error) (ParseErrorCode, bool) {
+	var (
+		parseErr   ParseError
+		exitErr    ExitError
+		configErr  ConfigFileError
+		respErr    ResponseFileError
+		subErr     UnknownSubcommandError
+		flagErr    UnknownFlagError
+		prefixErr  AmbiguousPrefixError
+		arityErr   FlagArityError
+		missArgErr MissingRequiredArgumentError
+		countErr   ArgumentCountError
+		tooManyErr TooManyArgumentsError
+		missErr    MissingFlagError
+		conErr     FlagConstraintError
+	)
+
+	switch {
+	case errors.As(err, &parseErr), errors.As(err, &exitErr), errors.As(err, &configErr), errors.As(err, &respErr):
+		return "", false
+	case errors.As(err, &subErr):
+		return ParseErrorUnknownSubcommand, true
+	case errors.As(err, &flagErr):
+		return ParseErrorUnknownFlag, true
+	case errors.As(err, &prefixErr):
+		if prefixErr.IsFlag() {
+			return ParseErrorUnknownFlag, true
+		}
+		return ParseErrorUnknownSubcommand, true
+	case errors.As(err, &arityErr):
+		return ParseErrorMissingFlagValue, true
+	case errors.As(err, &missArgErr):
+		if missArgErr.HasFlag() {
+			return ParseErrorMissingFlagValue, true
+		}
+		return ParseErrorMissingArgument, true
+	case errors.As(err, &countErr):
+		return ParseErrorMissingArgument, true
+	case errors.As(err, &tooManyErr):
+		return ParseErrorTooManyArguments, true
+	case errors.As(err, &missErr), errors.As(err, &conErr):
+		return ParseErrorConstraintViolation, true
+	default:
+		return ParseErrorInvalidValue, true
+	}
+}
@@ -0,0 +1,120 @@
+package argo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Standard process exit codes returned by ExitCode.
+const (
+	// ExitCodeSuccess is the exit code for a call that completed without error.
+	ExitCodeSuccess = 0
+
+	// ExitCodeFailure is the exit code for a call whose handler returned an
+	// error.
+	ExitCodeFailure = 1
+
+	// ExitCodeUsage is the exit code for a call that could not be parsed.
+	ExitCodeUsage = 2
+
+	// ExitCodeInterrupted is the exit code for a call that was cancelled by
+	// SIGINT or SIGTERM.
+	ExitCodeInterrupted = 130
+)
+
+// An ExitError is returned on CLI parse when parsing was halted because the
+// program was expected to exit, but the Runtime's exit function returned
+// rather than terminating the process.
+//
+// This happens when help text is printed for a help flag or completion
+// candidates are printed in dynamic completion mode, in which case the code
+// will be 0, or for a command tree call that did not reach a leaf command, in
+// which case the code will be 1.
+//
+// ExitErrors are also returned by Execute methods to wrap parsing errors with
+// the ExitCodeUsage code, and may be returned by handlers using WithExitCode
+// to request a specific exit code.
+type ExitError interface {
+	error
+
+	// Code returns the exit code the program is expected to exit with.
+	Code() int
+
+	// Unwrap returns the error that caused the exit, if any.
+	Unwrap() error
+}
+
+// WithExitCode wraps the given error in an ExitError with the given exit code.
+//
+// This may be used by command handlers to control the exit code that ExitCode
+// will return for the handler's error.
+//
+// Example:
+//     func(ctx context.Context, leaf argo.CommandLeaf) error {
+//         if err := deploy(ctx); err != nil {
+//             return argo.WithExitCode(err, 3)
+//         }
+//         return nil
+//     }
+func WithExitCode(err error, code int) ExitError {
+	return exitError{code, err}
+}
+
+// ExitCode returns the process exit code that should be used for the given
+// error returned from an Execute call.
+//
+// Errors are mapped to exit codes as follows:
+//     nil              -> ExitCodeSuccess
+//     ExitError        -> the ExitError's code
+//     context.Canceled -> ExitCodeInterrupted
+//     any other error  -> ExitCodeFailure
+//
+// Example:
+//     func main() {
+//         err := cli.Tree().
+//             WithLeaf(cli.Leaf("serve").WithHandler(serve)).
+//             Execute(context.Background(), os.Args)
+//         os.Exit(argo.ExitCode(err))
+//     }
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+
+	var exit ExitError
+	if errors.As(err, &exit) {
+		return exit.Code()
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ExitCodeInterrupted
+	}
+
+	return ExitCodeFailure
+}
+
+func newExitError(code int) ExitError {
+	return exitError{code: code}
+}
+
+type exitError struct {
+	code  int
+	cause error
+}
+
+func (e exitError) Error() string {
+	if e.cause != nil {
+		return e.cause.Error()
+	}
+
+	return fmt.Sprintf("exit requested with code %d", e.code)
+}
+
+func (e exitError) Code() int {
+	return e.code
+}
+
+func (e exitError) Unwrap() error {
+	return e.cause
+}
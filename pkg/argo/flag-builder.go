@@ -2,6 +2,7 @@ package argo
 
 import (
 	"errors"
+	"reflect"
 
 	"github.com/Foxcapades/Argonaut/internal/chars"
 )
@@ -76,6 +77,25 @@ type FlagBuilder interface {
 	// Only one argument may be set on a Flag at a time.
 	WithArgument(arg ArgumentBuilder) FlagBuilder
 
+	// WithArguments attaches the given arguments to the Flag being built,
+	// replacing any argument previously set.
+	//
+	// A Flag with more than one argument consumes one value per argument each
+	// time it is used, with the values following the flag on the CLI.  All the
+	// arguments of such a Flag are required when the Flag is used, and any
+	// defaults set on them are applied when it is not.
+	//
+	// Example Config:
+	//     cli.LongFlag("rename").
+	//         WithArguments(
+	//             cli.Argument().WithName("old").WithBinding(&oldName),
+	//             cli.Argument().WithName("new").WithBinding(&newName))
+	//
+	// Example Usage:
+	//     --rename foo bar
+	//     --rename=foo bar
+	WithArguments(args ...ArgumentBuilder) FlagBuilder
+
 	// WithBinding is a shortcut method for attaching an argument and binding it
 	// to the given pointer.
 	//
@@ -94,6 +114,129 @@ type FlagBuilder interface {
 	//     WithArgument(cli.Argument().WithBinding(ptr).WithDefault(something).Require())
 	WithBindingAndDefault(pointer, def any, required bool) FlagBuilder
 
+	// WithEnvVar sets the name of an environment variable that will be used as
+	// the source of this Flag's argument value when the Flag is not used in the
+	// CLI call.
+	//
+	// This is a shortcut for calling WithEnvVar on the Flag's ArgumentBuilder
+	// and requires that the Flag has an argument attached by the time it is
+	// built.
+	//
+	// Example:
+	//     cli.LongFlag("timeout").
+	//         WithBinding(&timeout, true).
+	//         WithEnvVar("APP_TIMEOUT")
+	WithEnvVar(name string) FlagBuilder
+
+	// RequiresFlag declares that the Flag being built may only be used in a CLI
+	// call if the named flag is also used.
+	//
+	// The given name may be a long form name with or without its leading dashes,
+	// or a short form character preceded by a single dash.  The named flag must
+	// be available on the same command, or for command trees, on the same node
+	// or one of its parents.
+	//
+	// A flag whose value is supplied by its environment variable or by a
+	// configuration file satisfies this requirement, a flag that only has its
+	// default value applied does not.
+	//
+	// This method may be called more than once to require multiple flags.
+	//
+	// Example:
+	//     cli.LongFlag("cert").RequiresFlag("key")
+	//     cli.LongFlag("cert").RequiresFlag("-k")
+	RequiresFlag(name string) FlagBuilder
+
+	// ConflictsWith declares that the Flag being built may not be used in the
+	// same CLI call as the named flag.
+	//
+	// The given name follows the same rules as the names passed to RequiresFlag.
+	// A flag whose value is supplied by its environment variable or by a
+	// configuration file counts as used, a flag that only has its default value
+	// applied does not.
+	//
+	// This method may be called more than once to declare multiple conflicts.
+	ConflictsWith(name string) FlagBuilder
+
+	// Hidden hides the Flag being built from rendered help text, documentation,
+	// JSON schemas, and shell completions.
+	//
+	// Hidden flags may still be used in a CLI call.
+	Hidden() FlagBuilder
+
+	// Deprecated marks the Flag being built as deprecated.
+	//
+	// When a deprecated flag is used in a CLI call, a warning including the
+	// given message is appended to the command's warnings.
+	//
+	// Example:
+	//     cli.LongFlag("colour").
+	//         Hidden().
+	//         Deprecated("use --color instead").
+	//         ReplacedBy("color")
+	Deprecated(message string) FlagBuilder
+
+	// ReplacedBy names a flag that will be used in place of the deprecated Flag
+	// being built when it appears in a CLI call.
+	//
+	// The given name follows the same rules as the names passed to RequiresFlag.
+	// The Flag being built must also be marked as deprecated.
+	ReplacedBy(name string) FlagBuilder
+
+	// WithCounter binds the given pointer as a counter that is incremented each
+	// time the Flag being built is used in the CLI call.
+	//
+	// Counting flags do not take an argument, and may be repeated or clustered
+	// in short flag blocks, for example `-vvv`.  The value pointed to before
+	// parsing acts as the base count.
+	//
+	// Example:
+	//     var level int
+	//     cli.ShortFlag('v').WithCounter(&level).WithCounterMax(3)
+	WithCounter(pointer *int) FlagBuilder
+
+	// WithDecrementCounter binds the given pointer as a counter that is
+	// decremented each time the Flag being built is used in the CLI call.
+	//
+	// This is used to pair a decrementing flag with a flag built with
+	// WithCounter by binding both to the same pointer.
+	//
+	// The counter will not be decremented below zero.  Additional uses of the
+	// flag once zero has been reached will not change the counter value.
+	//
+	// Example:
+	//     var level int
+	//     cli.Command().
+	//         WithFlag(cli.ShortFlag('v').WithCounter(&level)).
+	//         WithFlag(cli.ShortFlag('q').WithDecrementCounter(&level))
+	WithDecrementCounter(pointer *int) FlagBuilder
+
+	// WithCounterMax sets the maximum value that the counter bound with
+	// WithCounter may be incremented to.
+	//
+	// Additional uses of the flag once the maximum has been reached will not
+	// change the counter value.
+	WithCounterMax(max int) FlagBuilder
+
+	// Negatable marks the Flag being built as negatable, registering a negated
+	// `--no-<long>` form of the flag that sets its argument to false.
+	//
+	// Negatable flags must have a long form and an argument bound to a bool.
+	// This allows a flag whose value defaults to true, or that was set to true
+	// by an environment variable or configuration file, to be turned off on the
+	// command line.
+	//
+	// Example:
+	//     cli.LongFlag("color").
+	//         WithBindingAndDefault(&color, true, false).
+	//         Negatable()
+	//
+	// Example help text:
+	//     --[no-]color
+	Negatable() FlagBuilder
+
+	isNegatable() bool
+
 	setIsHelpFlag() FlagBuilder
 
 	// Require marks this Flag as being required.
@@ -113,13 +256,28 @@ func NewFlagBuilder() FlagBuilder {
 }
 
 type flagBuilder struct {
-	short  byte
-	req    bool
-	isHelp bool
-	long   string
-	desc   string
-	onHit  FlagCallback
-	arg    ArgumentBuilder
+	short     byte
+	req       bool
+	isHelp    bool
+	negatable bool
+	long      string
+	desc      string
+	envVar    string
+	onHit     FlagCallback
+	arg       ArgumentBuilder
+	extraArgs []ArgumentBuilder
+
+	requires  []string
+	conflicts []string
+
+	counter     *int
+	counterStep int
+	counterMax  int
+
+	hidden      bool
+	deprecated  bool
+	deprecation string
+	replacement string
 }
 
 func (b *flagBuilder) WithShortForm(char byte) FlagBuilder {
@@ -160,6 +318,19 @@ func (b *flagBuilder) WithCallback(fn func(Flag)) FlagBuilder {
 
 func (b *flagBuilder) WithArgument(arg ArgumentBuilder) FlagBuilder {
 	b.arg = arg
+	b.extraArgs = nil
+	return b
+}
+
+func (b *flagBuilder) WithArguments(args ...ArgumentBuilder) FlagBuilder {
+	if len(args) == 0 {
+		b.arg = nil
+		b.extraArgs = nil
+	} else {
+		b.arg = args[0]
+		b.extraArgs = args[1:]
+	}
+
 	return b
 }
 
@@ -170,6 +341,7 @@ func (b *flagBuilder) Require() FlagBuilder {
 
 func (b *flagBuilder) WithBinding(pointer any, required bool) FlagBuilder {
 	b.arg = NewArgumentBuilder().WithBinding(pointer)
+	b.extraArgs = nil
 
 	if required {
 		b.arg.Require()
@@ -180,6 +352,7 @@ func (b *flagBuilder) WithBinding(pointer any, required bool) FlagBuilder {
 
 func (b *flagBuilder) WithBindingAndDefault(pointer, def any, required bool) FlagBuilder {
 	b.arg = NewArgumentBuilder().WithBinding(pointer).WithDefault(def)
+	b.extraArgs = nil
 
 	if required {
 		b.arg.Require()
@@ -188,6 +361,63 @@ func (b *flagBuilder) WithBindingAndDefault(pointer, def any, required bool) Fla
 	return b
 }
 
+func (b *flagBuilder) WithEnvVar(name string) FlagBuilder {
+	b.envVar = name
+	return b
+}
+
+func (b *flagBuilder) RequiresFlag(name string) FlagBuilder {
+	b.requires = append(b.requires, name)
+	return b
+}
+
+func (b *flagBuilder) ConflictsWith(name string) FlagBuilder {
+	b.conflicts = append(b.conflicts, name)
+	return b
+}
+
+func (b *flagBuilder) Hidden() FlagBuilder {
+	b.hidden = true
+	return b
+}
+
+func (b *flagBuilder) Deprecated(message string) FlagBuilder {
+	b.deprecated = true
+	b.deprecation = message
+	return b
+}
+
+func (b *flagBuilder) ReplacedBy(name string) FlagBuilder {
+	b.replacement = name
+	return b
+}
+
+func (b *flagBuilder) WithCounter(pointer *int) FlagBuilder {
+	b.counter = pointer
+	b.counterStep = 1
+	return b
+}
+
+func (b *flagBuilder) WithDecrementCounter(pointer *int) FlagBuilder {
+	b.counter = pointer
+	b.counterStep = -1
+	return b
+}
+
+func (b *flagBuilder) WithCounterMax(max int) FlagBuilder {
+	b.counterMax = max
+	return b
+}
+
+func (b *flagBuilder) Negatable() FlagBuilder {
+	b.negatable = true
+	return b
+}
+
+func (b flagBuilder) isNegatable() bool {
+	return b.negatable
+}
+
 func (b *flagBuilder) setIsHelpFlag() FlagBuilder {
 	b.isHelp = true
 	return b
@@ -212,42 +442,129 @@ func (b *flagBuilder) Build(ctx *WarningContext) (Flag, error) {
 		errs.AppendError(errors.New("flag declared with neither a long or short form"))
 	}
 
-	var arg Argument
+	if len(b.envVar) > 0 {
+		if b.arg == nil {
+			errs.AppendError(errors.New("environment variable set on a flag with no argument"))
+		} else if len(b.extraArgs) > 0 {
+			errs.AppendError(errors.New("environment variable set on a flag with multiple arguments"))
+		} else {
+			b.arg.WithEnvVar(b.envVar)
+		}
+	}
+
+	requires := normalizeFlagRefs(b.requires, errs)
+	conflicts := normalizeFlagRefs(b.conflicts, errs)
+
+	var replacement string
+	if len(b.replacement) > 0 {
+		if !b.deprecated {
+			errs.AppendError(errors.New("replacement set on a flag that is not deprecated"))
+		}
+
+		if refs := normalizeFlagRefs([]string{b.replacement}, errs); len(refs) > 0 {
+			replacement = refs[0]
+		}
+	}
+
+	var args []Argument
 
 	if b.arg != nil {
-		var err error
-		arg, err = b.arg.Build(ctx)
-
-		if err != nil {
-			var e MultiError
-			if errors.As(err, &e) {
-				var be ArgumentBindingError
-				for _, err := range e.Errors() {
-					if errors.As(err, &be) {
-						errs.AppendError(newFlagBindingError(be, b.arg, b))
-					} else {
-						errs.AppendError(err)
+		builders := append([]ArgumentBuilder{b.arg}, b.extraArgs...)
+		args = make([]Argument, 0, len(builders))
+
+		for _, builder := range builders {
+			if builder.isVariadic() {
+				errs.AppendError(errors.New("flag arguments may not be variadic"))
+			}
+
+			if builder.isHidden() || builder.isDeprecated() {
+				errs.AppendError(errors.New("flag arguments may not be hidden or deprecated"))
+			}
+
+			// Flags with multiple arguments consume a fixed number of values.
+			if len(builders) > 1 {
+				builder.Require()
+			}
+
+			arg, err := builder.Build(ctx)
+
+			if err != nil {
+				var e MultiError
+				if errors.As(err, &e) {
+					var be ArgumentBindingError
+					for _, err := range e.Errors() {
+						if errors.As(err, &be) {
+							errs.AppendError(newFlagBindingError(be, builder, b))
+						} else {
+							errs.AppendError(err)
+						}
 					}
+				} else {
+					errs.AppendError(err)
 				}
 			} else {
-				errs.AppendError(err)
+				args = append(args, arg)
 			}
 		}
 	}
 
+	if b.counterStep != 0 {
+		if b.counter == nil {
+			errs.AppendError(errors.New("counting flags must be bound to a non-nil pointer"))
+		}
+
+		if b.arg != nil {
+			errs.AppendError(errors.New("counting flags may not have an argument"))
+		}
+
+		if b.counterMax < 0 {
+			errs.AppendError(errors.New("counting flag max count must not be negative"))
+		}
+
+		if b.counterMax > 0 && b.counterStep < 0 {
+			errs.AppendError(errors.New("counting flag max count may not be set on a decrementing flag"))
+		}
+	} else if b.counterMax != 0 {
+		errs.AppendError(errors.New("counting flag max count set on a flag with no counter"))
+	}
+
+	if b.negatable {
+		if !b.hasLongForm() {
+			errs.AppendError(errors.New("negatable flags must have a long form"))
+		}
+
+		if b.arg == nil || len(b.extraArgs) > 0 {
+			errs.AppendError(errors.New("negatable flags must have a single argument bound to a bool"))
+		} else if len(args) > 0 && (!args[0].HasBinding() || args[0].BindingType().Kind() != reflect.Bool) {
+			errs.AppendError(errors.New("negatable flags must have a single argument bound to a bool"))
+		}
+	}
+
 	if len(errs.Errors()) > 0 {
 		return nil, errs
 	}
 
 	return &flag{
-		warnings: ctx,
-		short:    b.short,
-		required: b.req,
-		arg:      arg,
-		long:     b.long,
-		desc:     b.desc,
-		isHelp:   b.isHelp,
-		callback: b.onHit,
+		warnings:  ctx,
+		short:     b.short,
+		required:  b.req,
+		args:      args,
+		long:      b.long,
+		desc:      b.desc,
+		isHelp:    b.isHelp,
+		negatable: b.negatable,
+		callback:  b.onHit,
+		requires:  requires,
+		conflicts: conflicts,
+
+		counter:     b.counter,
+		counterStep: b.counterStep,
+		counterMax:  b.counterMax,
+
+		hidden:      b.hidden,
+		deprecated:  b.deprecated,
+		deprecation: b.deprecation,
+		replacement: replacement,
 	}, nil
 }
 
@@ -54,3 +54,177 @@ func TestFlagBuilder_Build05(t *testing.T) {
 	}
 	t.Log(err)
 }
+
+// env var with no argument
+func TestFlagBuilder_Build06(t *testing.T) {
+	_, err := cli.Flag().
+		WithLongForm("test").
+		WithEnvVar("TEST").
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+// blank env var name
+func TestFlagBuilder_Build07(t *testing.T) {
+	var value string
+	_, err := cli.Flag().
+		WithLongForm("test").
+		WithBinding(&value, false).
+		WithEnvVar(" ").
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+// invalid required flag reference
+func TestFlagBuilder_Build08(t *testing.T) {
+	_, err := cli.Flag().
+		WithLongForm("test").
+		RequiresFlag("-long").
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+// variadic flag argument
+func TestFlagBuilder_Build09(t *testing.T) {
+	_, err := cli.Flag().
+		WithLongForm("test").
+		WithArgument(cli.Argument().Variadic()).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+// negatable flag without a boolean argument
+func TestFlagBuilder_Build10(t *testing.T) {
+	var value string
+	_, err := cli.Flag().
+		WithLongForm("test").
+		WithBinding(&value, false).
+		Negatable().
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+// negatable flag without a long form
+func TestFlagBuilder_Build11(t *testing.T) {
+	var value bool
+	_, err := cli.Flag().
+		WithShortForm('t').
+		WithBinding(&value, false).
+		Negatable().
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+func TestFlagBuilder_Build12(t *testing.T) {
+	var level int
+	_, err := cli.Flag().
+		WithShortForm('v').
+		WithCounter(&level).
+		WithBinding(&level, false).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+func TestFlagBuilder_Build13(t *testing.T) {
+	_, err := cli.Flag().
+		WithShortForm('v').
+		WithCounterMax(3).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+func TestFlagBuilder_Build14(t *testing.T) {
+	_, err := cli.Flag().
+		WithShortForm('v').
+		WithCounter(nil).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+func TestFlagBuilder_Build15(t *testing.T) {
+	var a, b string
+	_, err := cli.Flag().
+		WithLongForm("rename").
+		WithArguments(
+			cli.Argument().WithBinding(&a),
+			cli.Argument().WithBinding(&b)).
+		WithEnvVar("ARGO_TEST_RENAME").
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+func TestFlagBuilder_Build16(t *testing.T) {
+	var a, b string
+	flag, err := cli.Flag().
+		WithLongForm("rename").
+		WithArguments(
+			cli.Argument().WithBinding(&a),
+			cli.Argument().WithBinding(&b)).
+		Build(nil)
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(flag.Arguments()) != 2 {
+		t.Error("expected flag to have 2 arguments but had", len(flag.Arguments()))
+	}
+
+	for _, arg := range flag.Arguments() {
+		if !arg.IsRequired() {
+			t.Error("expected all flag arguments to be required")
+		}
+	}
+}
+
+func TestFlagBuilder_Build17(t *testing.T) {
+	_, err := cli.Flag().
+		WithLongForm("colour").
+		ReplacedBy("color").
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
+
+func TestFlagBuilder_Build18(t *testing.T) {
+	_, err := cli.Flag().
+		WithLongForm("output").
+		WithArgument(cli.Argument().Deprecated("")).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err to not have been nil, but it was")
+	}
+}
@@ -0,0 +1,168 @@
+package argo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+	"github.com/Foxcapades/Argonaut/internal/util"
+)
+
+// flagFinder is implemented by any component that can look up flags by their
+// short or long forms.
+type flagFinder interface {
+	FindShortFlag(c byte) Flag
+	FindLongFlag(name string) Flag
+
+	// findExactLongFlag looks up a flag by its full long form name, ignoring
+	// abbreviations.
+	findExactLongFlag(name string) Flag
+}
+
+// normalizeFlagRefs converts the given flag names into their CLI forms, for
+// example "--key" or "-k", appending an error for each invalid name.
+func normalizeFlagRefs(refs []string, errs MultiError) []string {
+	out := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		switch {
+		case strings.HasPrefix(ref, chars.StrDoubleDash):
+			if len(ref) == 2 {
+				errs.AppendError(fmt.Errorf("invalid flag reference \"%s\"", ref))
+			} else if err := validateLongForm(ref[2:]); err != nil {
+				errs.AppendError(fmt.Errorf("invalid flag reference \"%s\": %s", ref, err))
+			} else {
+				out = append(out, ref)
+			}
+
+		case strings.HasPrefix(ref, chars.StrDash):
+			if len(ref) != 2 {
+				errs.AppendError(fmt.Errorf("invalid flag reference \"%s\"", ref))
+			} else if err := validateShortForm(ref[1]); err != nil {
+				errs.AppendError(fmt.Errorf("invalid flag reference \"%s\": %s", ref, err))
+			} else {
+				out = append(out, ref)
+			}
+
+		default:
+			if len(ref) == 0 {
+				errs.AppendError(fmt.Errorf("invalid flag reference \"%s\"", ref))
+			} else if err := validateLongForm(ref); err != nil {
+				errs.AppendError(fmt.Errorf("invalid flag reference \"%s\": %s", ref, err))
+			} else {
+				out = append(out, chars.StrDoubleDash+ref)
+			}
+		}
+	}
+
+	return out
+}
+
+// findFlagByRef looks up the flag referenced by the given normalized flag name.
+//
+// Flag references are always matched exactly, even when abbreviations are
+// enabled.
+func findFlagByRef(finder flagFinder, ref string) Flag {
+	if strings.HasPrefix(ref, chars.StrDoubleDash) {
+		return finder.findExactLongFlag(ref[2:])
+	}
+
+	return finder.FindShortFlag(ref[1])
+}
+
+// validateFlagReferences ensures that every flag referenced by the flags in the
+// given groups can be found using the given finder.
+func validateFlagReferences(finder flagFinder, groups []FlagGroup, errs MultiError) {
+	for _, group := range groups {
+		for _, flag := range group.Flags() {
+			for _, ref := range flag.RequiredFlags() {
+				if target := findFlagByRef(finder, ref); target == nil {
+					errs.AppendError(fmt.Errorf("flag %s requires unknown flag %s", printFlagNames(flag), ref))
+				} else if target == flag {
+					errs.AppendError(fmt.Errorf("flag %s requires itself", printFlagNames(flag)))
+				}
+			}
+
+			for _, ref := range flag.ConflictingFlags() {
+				if target := findFlagByRef(finder, ref); target == nil {
+					errs.AppendError(fmt.Errorf("flag %s conflicts with unknown flag %s", printFlagNames(flag), ref))
+				} else if target == flag {
+					errs.AppendError(fmt.Errorf("flag %s conflicts with itself", printFlagNames(flag)))
+				}
+			}
+
+			if flag.HasReplacement() {
+				if target := findFlagByRef(finder, flag.Replacement()); target == nil {
+					errs.AppendError(fmt.Errorf("flag %s is replaced by unknown flag %s", printFlagNames(flag), flag.Replacement()))
+				} else if target == flag {
+					errs.AppendError(fmt.Errorf("flag %s is replaced by itself", printFlagNames(flag)))
+				}
+			}
+		}
+	}
+}
+
+// validateTreeFlagReferences calls validateFlagReferences for the given node
+// and all of its descendants.
+func validateTreeFlagReferences(node CommandNode, errs MultiError) {
+	validateFlagReferences(node, node.FlagGroups(), errs)
+
+	if parent, ok := node.(CommandParent); ok {
+		for _, group := range parent.CommandGroups() {
+			for _, branch := range group.Branches() {
+				validateTreeFlagReferences(branch, errs)
+			}
+			for _, leaf := range group.Leaves() {
+				validateTreeFlagReferences(leaf, errs)
+			}
+		}
+	}
+}
+
+// checkFlagConstraints tests the flag group and flag relationship constraints
+// for the given flag groups against the flags that were used in the CLI call,
+// appending a FlagConstraintError for every violated constraint.
+//
+// Flags whose values were supplied by their environment variable or a
+// configuration file are treated as though they were used in the CLI call.
+func checkFlagConstraints(finder flagFinder, groups []FlagGroup, errs MultiError) {
+	for _, group := range groups {
+		if group.IsMutuallyExclusive() {
+			used := make([]Flag, 0, 2)
+
+			for _, flag := range group.Flags() {
+				if flag.isPresent() {
+					used = append(used, flag)
+				}
+			}
+
+			if len(used) > 1 {
+				errs.AppendError(newFlagConstraintError(util.IfElse(
+					group.RequiresExactlyOne(),
+					FlagConstraintExactlyOne,
+					FlagConstraintMutuallyExclusive,
+				), used))
+			} else if len(used) == 0 && group.RequiresExactlyOne() {
+				errs.AppendError(newFlagConstraintError(FlagConstraintExactlyOne, group.Flags()))
+			}
+		}
+
+		for _, flag := range group.Flags() {
+			if !flag.isPresent() {
+				continue
+			}
+
+			for _, ref := range flag.RequiredFlags() {
+				if target := findFlagByRef(finder, ref); target != nil && !target.isPresent() {
+					errs.AppendError(newFlagConstraintError(FlagConstraintRequires, []Flag{flag, target}))
+				}
+			}
+
+			for _, ref := range flag.ConflictingFlags() {
+				if target := findFlagByRef(finder, ref); target != nil && target.isPresent() {
+					errs.AppendError(newFlagConstraintError(FlagConstraintConflicts, []Flag{flag, target}))
+				}
+			}
+		}
+	}
+}
@@ -20,6 +20,20 @@ type FlagGroupBuilder interface {
 	// WithFlag appends the given FlagBuilder instance to this FlagGroupBuilder.
 	WithFlag(flag FlagBuilder) FlagGroupBuilder
 
+	// MutuallyExclusive marks the flags in this group as being mutually
+	// exclusive, meaning at most one of them may be used in a CLI call.
+	//
+	// If more than one flag in the group is used, a FlagConstraintError will be
+	// returned when parsing the CLI input.
+	MutuallyExclusive() FlagGroupBuilder
+
+	// ExactlyOne marks the flags in this group as being mutually exclusive and
+	// requires that exactly one of them is used in a CLI call.
+	//
+	// If none or more than one of the flags in the group are used, a
+	// FlagConstraintError will be returned when parsing the CLI input.
+	ExactlyOne() FlagGroupBuilder
+
 	hasFlags() bool
 	size() int
 	getFlags() []FlagBuilder
@@ -33,9 +47,10 @@ func NewFlagGroupBuilder(name string) FlagGroupBuilder {
 }
 
 type flagGroupBuilder struct {
-	name  string
-	desc  string
-	flags []FlagBuilder
+	name       string
+	desc       string
+	flags      []FlagBuilder
+	constraint flagGroupConstraint
 }
 
 func (g flagGroupBuilder) getName() string {
@@ -52,6 +67,16 @@ func (g *flagGroupBuilder) WithFlag(flag FlagBuilder) FlagGroupBuilder {
 	return g
 }
 
+func (g *flagGroupBuilder) MutuallyExclusive() FlagGroupBuilder {
+	g.constraint = flagGroupConstraintMutuallyExclusive
+	return g
+}
+
+func (g *flagGroupBuilder) ExactlyOne() FlagGroupBuilder {
+	g.constraint = flagGroupConstraintExactlyOne
+	return g
+}
+
 func (g flagGroupBuilder) hasFlags() bool {
 	return len(g.flags) > 0
 }
@@ -86,9 +111,10 @@ func (g *flagGroupBuilder) Build(ctx *WarningContext) (FlagGroup, error) {
 	}
 
 	return &flagGroup{
-		warnings: ctx,
-		name:     g.name,
-		desc:     g.desc,
-		flags:    flags,
+		warnings:   ctx,
+		name:       g.name,
+		desc:       g.desc,
+		flags:      flags,
+		constraint: g.constraint,
 	}, nil
 }
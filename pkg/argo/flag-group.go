@@ -33,14 +33,31 @@ type FlagGroup interface {
 	// long flag name.  If one could not be found, this method returns nil.
 	FindLongFlag(name string) Flag
 
+	// IsMutuallyExclusive indicates whether at most one of the flags in this
+	// FlagGroup may be used in a CLI call.
+	IsMutuallyExclusive() bool
+
+	// RequiresExactlyOne indicates whether exactly one of the flags in this
+	// FlagGroup must be used in a CLI call.
+	RequiresExactlyOne() bool
+
 	size() int
 }
 
+type flagGroupConstraint uint8
+
+const (
+	flagGroupConstraintNone flagGroupConstraint = iota
+	flagGroupConstraintMutuallyExclusive
+	flagGroupConstraintExactlyOne
+)
+
 type flagGroup struct {
-	warnings *WarningContext
-	name     string
-	desc     string
-	flags    []Flag
+	warnings   *WarningContext
+	name       string
+	desc       string
+	flags      []Flag
+	constraint flagGroupConstraint
 }
 
 func (f flagGroup) Name() string {
@@ -79,6 +96,14 @@ func (f flagGroup) FindLongFlag(name string) Flag {
 	return nil
 }
 
+func (f flagGroup) IsMutuallyExclusive() bool {
+	return f.constraint != flagGroupConstraintNone
+}
+
+func (f flagGroup) RequiresExactlyOne() bool {
+	return f.constraint == flagGroupConstraintExactlyOne
+}
+
 func (f flagGroup) size() int {
 	return len(f.flags)
 }
@@ -2,8 +2,103 @@ package argo
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
 )
 
+// negatedFlagPrefix is the prefix of the negated long form of a negatable
+// flag, for example the "no-" in "--no-color".
+const negatedFlagPrefix = "no-"
+
+// findNegatedLongFlag looks up the negatable flag that the given long form name
+// is the negated form of.
+//
+// If the name is not the negated form of a negatable flag, this function
+// returns nil.  Negated forms are never abbreviated, the name must contain the
+// full long form of the flag.
+func findNegatedLongFlag(finder flagFinder, name string) Flag {
+	if !strings.HasPrefix(name, negatedFlagPrefix) {
+		return nil
+	}
+
+	if flag := finder.findExactLongFlag(name[len(negatedFlagPrefix):]); flag != nil && flag.IsNegatable() {
+		return flag
+	}
+
+	return nil
+}
+
+// hasLongFlag indicates whether the given long form name refers to a flag,
+// either directly or as the negated form of a negatable flag.
+func hasLongFlag(finder flagFinder, name string) bool {
+	return finder.FindLongFlag(name) != nil || findNegatedLongFlag(finder, name) != nil
+}
+
+// lookupLongFlag looks up a flag by its long form name in the given flag
+// groups, in order.
+//
+// If no flag has exactly the given name and abbreviations are enabled, the flag
+// whose long form name is uniquely prefixed by the given name is returned
+// instead.  If more than one flag is prefixed by the given name, an
+// AmbiguousPrefixError is returned.  Hidden flags are only matched by their
+// full name.
+func lookupLongFlag(groups []FlagGroup, name string, abbreviations bool) (Flag, error) {
+	for _, group := range groups {
+		if flag := group.FindLongFlag(name); flag != nil {
+			return flag, nil
+		}
+	}
+
+	if !abbreviations || len(name) == 0 {
+		return nil, nil
+	}
+
+	var match Flag
+	candidates := make([]string, 0, 4)
+	seen := make(map[string]bool, 16)
+
+	for _, group := range groups {
+		for _, flag := range group.Flags() {
+			if !flag.HasLongForm() || seen[flag.LongForm()] {
+				continue
+			}
+
+			seen[flag.LongForm()] = true
+
+			if !flag.IsHidden() && strings.HasPrefix(flag.LongForm(), name) {
+				match = flag
+				candidates = append(candidates, chars.StrDoubleDash+flag.LongForm())
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return match, nil
+	default:
+		return nil, newAmbiguousPrefixError(name, candidates, true)
+	}
+}
+
+// nodeFlagGroups returns the flag groups of the given node followed by the
+// flag groups of each of its parents, in lookup priority order.
+func nodeFlagGroups(node CommandNode) []FlagGroup {
+	out := make([]FlagGroup, 0, 8)
+
+	for {
+		out = append(out, node.FlagGroups()...)
+
+		if !node.HasParent() {
+			return out
+		}
+
+		node = node.Parent()
+	}
+}
+
 func printFlagNames(flag Flag) string {
 	if flag.HasLongForm() {
 		if flag.HasShortForm() {
@@ -27,6 +122,14 @@ func uniqueFlagNames(groups []FlagGroupBuilder, errs MultiError) {
 				if longs[flag.getLongForm()] == 2 {
 					errs.AppendError(fmt.Errorf("conflicting flag longform name %s", flag.getLongForm()))
 				}
+
+				if flag.isNegatable() {
+					negated := negatedFlagPrefix + flag.getLongForm()
+					longs[negated]++
+					if longs[negated] == 2 {
+						errs.AppendError(fmt.Errorf("conflicting flag longform name %s", negated))
+					}
+				}
 			}
 
 			if flag.hasShortForm() {
@@ -38,3 +141,13 @@ func uniqueFlagNames(groups []FlagGroupBuilder, errs MultiError) {
 		}
 	}
 }
+
+func printFlagList(flags []Flag) string {
+	names := make([]string, len(flags))
+
+	for i, flag := range flags {
+		names[i] = printFlagNames(flag)
+	}
+
+	return strings.Join(names, ", ")
+}
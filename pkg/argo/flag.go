@@ -1,7 +1,5 @@
 package argo
 
-import "fmt"
-
 // Flag represents a single CLI flag which may have an argument.
 type Flag interface {
 
@@ -32,6 +30,14 @@ type Flag interface {
 	// HasArgument indicates whether this Flag accepts an argument.
 	HasArgument() bool
 
+	// Arguments returns all the arguments attached to this Flag in the order
+	// their values are expected on the CLI.
+	//
+	// Flags built with more than one argument consume one value per argument
+	// each time they are used.  If this Flag does not have any arguments
+	// attached, this method will return an empty slice.
+	Arguments() []Argument
+
 	// IsRequired indicates whether this Flag is required.
 	//
 	// Required flags must be present in the CLI call.
@@ -48,12 +54,65 @@ type Flag interface {
 	// call.
 	HitCount() int
 
+	// IsCounter indicates whether this Flag is a counting flag that increments
+	// or decrements a bound integer each time it is used.
+	IsCounter() bool
+
+	// IsNegatable indicates whether this Flag may be used in its negated
+	// `--no-<long>` form to set its boolean argument to false.
+	IsNegatable() bool
+
+	// WasNegated indicates whether the last use of this Flag in the CLI call was
+	// its negated `--no-<long>` form.
+	WasNegated() bool
+
+	// RequiredFlags returns the names of the flags that must also be used in a
+	// CLI call when this Flag is used.
+	//
+	// Names are returned in their CLI form, for example "--key" or "-k".
+	RequiredFlags() []string
+
+	// ConflictingFlags returns the names of the flags that may not be used in
+	// the same CLI call as this Flag.
+	//
+	// Names are returned in their CLI form, for example "--key" or "-k".
+	ConflictingFlags() []string
+
+	// IsHidden indicates whether this Flag is hidden from rendered help text,
+	// documentation, and shell completions.
+	IsHidden() bool
+
+	// IsDeprecated indicates whether this Flag has been marked as deprecated.
+	IsDeprecated() bool
+
+	// DeprecationMessage returns the message included in the warning recorded
+	// when this Flag is used, if it is deprecated.
+	DeprecationMessage() string
+
+	// HasReplacement indicates whether this Flag names a replacement flag that is
+	// used in its place.
+	HasReplacement() bool
+
+	// Replacement returns the name of the flag that is used in place of this
+	// Flag, in its CLI form, for example "--color".
+	Replacement() string
+
 	AppendWarning(warning string)
 
 	isHelpFlag() bool
 	hit() error
 	hitWithArg(rawArg string) error
+	hitWithArgs(rawArgs []string) error
+	negate() error
 	executeCallback()
+
+	// markSupplied records that this Flag's value was supplied by its
+	// environment variable or a configuration file rather than the CLI call.
+	markSupplied()
+
+	// isPresent indicates whether this Flag was used in the CLI call or had its
+	// value supplied by its environment variable or a configuration file.
+	isPresent() bool
 }
 
 // A FlagCallback is a function that, if set on a flag, will be called by the
@@ -65,17 +124,32 @@ type FlagCallback = func(flag Flag)
 type flag struct {
 	hits uint16
 
-	short    byte
-	required bool
-	isHelp   bool
+	short     byte
+	required  bool
+	isHelp    bool
+	negatable bool
+	negated   bool
+	supplied  bool
 
-	arg Argument
+	counter     *int
+	counterStep int
+	counterMax  int
+
+	args []Argument
 
 	long string
 	desc string
 
 	callback FlagCallback
 	warnings *WarningContext
+
+	requires  []string
+	conflicts []string
+
+	hidden      bool
+	deprecated  bool
+	deprecation string
+	replacement string
 }
 
 func (f flag) ShortForm() byte {
@@ -103,11 +177,19 @@ func (f flag) HasDescription() bool {
 }
 
 func (f flag) Argument() Argument {
-	return f.arg
+	if len(f.args) > 0 {
+		return f.args[0]
+	}
+
+	return nil
 }
 
 func (f flag) HasArgument() bool {
-	return f.arg != nil
+	return len(f.args) > 0
+}
+
+func (f flag) Arguments() []Argument {
+	return f.args
 }
 
 func (f flag) IsRequired() bool {
@@ -115,7 +197,35 @@ func (f flag) IsRequired() bool {
 }
 
 func (f flag) RequiresArgument() bool {
-	return f.arg != nil && f.arg.IsRequired()
+	return f.HasArgument() && f.args[0].IsRequired()
+}
+
+func (f flag) RequiredFlags() []string {
+	return f.requires
+}
+
+func (f flag) ConflictingFlags() []string {
+	return f.conflicts
+}
+
+func (f flag) IsHidden() bool {
+	return f.hidden
+}
+
+func (f flag) IsDeprecated() bool {
+	return f.deprecated
+}
+
+func (f flag) DeprecationMessage() string {
+	return f.deprecation
+}
+
+func (f flag) HasReplacement() bool {
+	return len(f.replacement) > 0
+}
+
+func (f flag) Replacement() string {
+	return f.replacement
 }
 
 func (f flag) isHelpFlag() bool {
@@ -124,12 +234,19 @@ func (f flag) isHelpFlag() bool {
 
 func (f *flag) hit() error {
 	f.hits++
-	if f.HasArgument() && f.arg.IsRequired() {
-		return fmt.Errorf("flag %s requires an input", printFlagNames(f))
+	f.negated = false
+
+	if f.counter != nil {
+		f.count()
+		return nil
+	}
+
+	if f.RequiresArgument() {
+		return newMissingRequiredFlagArgumentError(f.args[0], f, nil)
 	}
 
 	if hasBooleanArgument(f) {
-		return f.arg.setValue("true")
+		return f.args[0].setValue("true")
 	}
 
 	return nil
@@ -137,14 +254,63 @@ func (f *flag) hit() error {
 
 func (f *flag) hitWithArg(rawArg string) error {
 	f.hits++
+	f.negated = false
 
-	if f.arg != nil {
-		return f.arg.setValue(rawArg)
+	if f.HasArgument() {
+		return f.args[0].setValue(rawArg)
 	} else {
 		return nil // TODO: warning for this
 	}
 }
 
+// hitWithArgs marks this flag as hit, setting the given raw values on this
+// flag's arguments in order.
+func (f *flag) hitWithArgs(rawArgs []string) error {
+	f.hits++
+	f.negated = false
+
+	for i := 0; i < len(rawArgs) && i < len(f.args); i++ {
+		if err := f.args[i].setValue(rawArgs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// count applies this flag's counter step to its bound counter, capping the
+// counter at the flag's max count, if it has one.
+func (f *flag) count() {
+	// Decrementing counters stop at zero.
+	if f.counterStep < 0 && *f.counter <= 0 {
+		return
+	}
+
+	*f.counter += f.counterStep
+
+	if f.counterMax > 0 && *f.counter > f.counterMax {
+		*f.counter = f.counterMax
+	}
+}
+
+func (f flag) IsCounter() bool {
+	return f.counter != nil
+}
+
+func (f *flag) negate() error {
+	f.hits++
+	f.negated = true
+	return f.args[0].setValue("false")
+}
+
+func (f flag) IsNegatable() bool {
+	return f.negatable
+}
+
+func (f flag) WasNegated() bool {
+	return f.negated
+}
+
 func (f flag) WasHit() bool {
 	return f.hits > 0
 }
@@ -153,6 +319,14 @@ func (f flag) HitCount() int {
 	return int(f.hits)
 }
 
+func (f *flag) markSupplied() {
+	f.supplied = true
+}
+
+func (f flag) isPresent() bool {
+	return f.hits > 0 || f.supplied
+}
+
 func (f flag) AppendWarning(warning string) {
 	f.warnings.appendWarning(warning)
 }
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/Foxcapades/Argonaut/internal/chars"
@@ -23,6 +22,23 @@ type commandTreeInterpreter struct {
 	queue    util.Deque[parse.Element]
 
 	flagHits flagQueue
+
+	// completion indicates that the interpreter is running in tolerant
+	// completion mode, where value and requirement errors are ignored and no
+	// callbacks are executed.
+	completion bool
+
+	// awaiting is the flag, if any, that was left waiting on an argument value
+	// when the end of the input was reached.
+	awaiting Flag
+
+	// positional indicates that interspersed flags are disabled for the selected
+	// leaf and a positional argument has been reached, making every remaining
+	// input a positional argument.
+	positional bool
+
+	// last is the most recently consumed element, not including the end marker.
+	last parse.Element
 }
 
 func (c *commandTreeInterpreter) next() parse.Element {
@@ -30,7 +46,37 @@ func (c *commandTreeInterpreter) next() parse.Element {
 		c.queue.Offer(c.parser.Next())
 	}
 
-	return c.queue.Poll()
+	next := c.queue.Poll()
+
+	if next.Type != parse.ElementTypeEnd {
+		c.last = next
+	}
+
+	return next
+}
+
+// parseError wraps the given error in a ParseError attributed to the given
+// element, if the error was caused by the CLI inputs.
+func (c *commandTreeInterpreter) parseError(err error, element *parse.Element) error {
+	return toParseError(err, commandPath(c.current), element, &c.last)
+}
+
+// unknownFlag records the given unknown flag error as a warning, or returns it
+// if strict parsing is enabled for the current node.
+func (c *commandTreeInterpreter) unknownFlag(err error, element *parse.Element) error {
+	err = c.parseError(err, element)
+
+	strict := c.tree.strictParsing()
+	if leaf, ok := c.current.(CommandLeaf); ok {
+		strict = leaf.strictParsing()
+	}
+
+	if strict {
+		return err
+	}
+
+	c.tree.appendWarningError(err)
+	return nil
 }
 
 func (c *commandTreeInterpreter) Run() error {
@@ -53,6 +99,17 @@ FOR:
 			continue
 		}
 
+		if c.positional {
+			if element.Type == parse.ElementTypeEnd {
+				break
+			}
+
+			if err := c.leaf.appendArgument(element.String()); err != nil && !c.completion {
+				return c.parseError(err, &element)
+			}
+			continue
+		}
+
 		switch element.Type {
 		case parse.ElementTypePlainText:
 			// If we've hit the leaf node, then the plain text becomes an argument on
@@ -60,46 +117,67 @@ FOR:
 			// plaintext value as the name of the next node in the tree.  If no such
 			// node exists, that is an error.
 			if node, ok := c.current.(CommandLeaf); ok {
-				if err := node.appendArgument(element.String()); err != nil {
-					return err
+				if err := node.appendArgument(element.String()); err != nil && !c.completion {
+					return c.parseError(err, &element)
 				}
+
+				c.positional = !node.allowsInterspersed()
 			} else if node, ok := c.current.(CommandParent); ok {
 				// Lookup a child with the given input string
-				if child := node.FindChild(element.String()); child != nil {
+				child, err := node.resolveChild(element.String())
+				if err != nil {
+					return c.parseError(err, &element)
+				}
+
+				if child != nil {
+					if child.IsDeprecated() {
+						c.tree.AppendWarning(childDeprecationWarning(child))
+						child = replacementChild(node, child)
+					}
+
 					c.current = child
 
 					if branch, ok := child.(CommandBranch); ok {
 						c.branches = append(c.branches, branch)
 					} else if leaf, ok := child.(CommandLeaf); ok {
 						c.leaf = leaf
+						c.tree.selectCommand(leaf)
 					}
 				} else {
 					// If node child could be found matching the input string, then print
 					// out a help message about the invalid subcommand.
-					return c.invalidSubCommand(element.String())
+					return c.parseError(c.invalidSubCommand(element.String()), &element)
 				}
 			} else {
 				panic("illegal state: command node was neither a leaf or a parent")
 			}
 
 		case parse.ElementTypeLongFlagPair:
-			if err := c.interpretLongPair(&element, &unmapped); err != nil {
-				return err
+			if err := c.interpretLongPair(&element, &unmapped); err != nil && !c.completion {
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeLongFlagSolo:
-			if err := c.interpretLongSolo(&element, &unmapped); err != nil {
-				return err
+			if err := c.interpretLongSolo(&element, &unmapped); err != nil && !c.completion {
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeShortBlockSolo:
-			if err := c.interpretShortSolo(&element, &unmapped); err != nil {
-				return err
+			// Negative numbers passed to a leaf are positional argument values, not
+			// short flags.
+			if node, ok := c.current.(CommandLeaf); ok && isNegativeNumberValue(&element, nextPositionalArgument(node.Arguments()), node) {
+				if err := node.appendArgument(element.String()); err != nil && !c.completion {
+					return c.parseError(err, &element)
+				}
+
+				c.positional = !node.allowsInterspersed()
+			} else if err := c.interpretShortSolo(&element, &unmapped); err != nil && !c.completion {
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeShortBlockPair:
-			if err := c.interpretShortPair(&element, &unmapped); err != nil {
-				return err
+			if err := c.interpretShortPair(&element, &unmapped); err != nil && !c.completion {
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeBoundary:
@@ -113,6 +191,10 @@ FOR:
 		}
 	}
 
+	if c.completion {
+		return nil
+	}
+
 	errs := newMultiError()
 	var onIncomplete func(parent CommandParent)
 
@@ -132,37 +214,37 @@ FOR:
 			node.appendPassthrough(value)
 		}
 
-		c.checkRequiredArgsWereHit(node.Arguments(), errs)
+		c.checkRequiredArgsWereHit(node, errs)
+	}
+
+	config, err := c.tree.getConfigLoader().load(c.current)
+	if err != nil {
+		errs.AppendError(err)
 	}
 
-	c.checkRequiredFlagsWereHit(c.current, errs)
+	c.checkRequiredFlagsWereHit(c.current, config, errs)
 
+	// If a help flag was used, it takes priority over all other callbacks.
 	it := c.flagHits.iterator()
-	hf := 0
 	for it.hasNext() {
-		flag := it.next()
-		if flag.isHelpFlag() {
+		if flag := it.next(); flag.isHelpFlag() {
 			flag.executeCallback()
-			break
+			return newExitError(0)
 		}
-		hf++
 	}
 
 	it = c.flagHits.iterator()
-	cf := 0
 	for it.hasNext() {
-		if cf != hf {
-			it.next().executeCallback()
-		}
-		cf++
+		it.next().executeCallback()
 	}
 
 	if onIncomplete != nil {
 		onIncomplete(c.current.(CommandParent))
+		return newExitError(1)
 	}
 
 	if len(errs.Errors()) > 0 {
-		return errs
+		return toParseErrors(errs, commandPath(c.current))
 	}
 
 	if c.tree.hasCallback() {
@@ -179,20 +261,25 @@ FOR:
 		c.leaf.executeCallback()
 	}
 
-	c.tree.selectCommand(c.leaf)
-
 	return nil
 }
 
-func (c *commandTreeInterpreter) checkRequiredArgsWereHit(args []Argument, errs MultiError) {
-	for i, arg := range args {
+func (c *commandTreeInterpreter) checkRequiredArgsWereHit(leaf CommandLeaf, errs MultiError) {
+	for _, arg := range leaf.Arguments() {
+		if !arg.WasHit() {
+			if used, err := arg.setToEnv(c.tree.getRuntime()); err != nil {
+				errs.AppendError(err)
+				continue
+			} else if used {
+				continue
+			}
+		}
+
 		if arg.IsRequired() {
 			if !arg.WasHit() {
-				if arg.HasName() {
-					errs.AppendError(fmt.Errorf("argument %d (<%s>) is required", i+1, arg.Name()))
-				} else {
-					errs.AppendError(fmt.Errorf("argument %d is required", i+1))
-				}
+				errs.AppendError(newMissingRequiredPositionalArgumentError(arg, leaf))
+			} else if len(arg.RawValues()) < arg.MinCount() {
+				errs.AppendError(newArgumentCountError(arg))
 			}
 		} else if !arg.WasHit() && arg.HasDefault() {
 			if err := arg.setToDefault(); err != nil {
@@ -202,26 +289,52 @@ func (c *commandTreeInterpreter) checkRequiredArgsWereHit(args []Argument, errs
 	}
 }
 
-func (c *commandTreeInterpreter) checkRequiredFlagsWereHit(current CommandNode, errs MultiError) {
+func (c *commandTreeInterpreter) checkRequiredFlagsWereHit(current CommandNode, config *configFile, errs MultiError) {
+	selected := current
+
 	for current != nil {
+		path := commandPath(current)[1:]
+
 		for _, group := range current.FlagGroups() {
 			for _, f := range group.Flags() {
+				if !f.WasHit() && f.HasArgument() {
+					if used, err := f.Argument().setToEnv(c.tree.getRuntime()); err != nil {
+						errs.AppendError(err)
+						continue
+					} else if used {
+						f.markSupplied()
+						continue
+					}
+
+					if used, err := config.applyFlag(path, f); err != nil {
+						errs.AppendError(err)
+						continue
+					} else if used {
+						f.markSupplied()
+						continue
+					}
+				}
+
 				if f.IsRequired() {
 					if !f.WasHit() {
-						errs.AppendError(fmt.Errorf("required flag %s was not used", printFlagNames(f)))
+						errs.AppendError(newMissingFlagError(f))
 					} else if f.RequiresArgument() && !f.Argument().WasHit() {
-						errs.AppendError(fmt.Errorf("flag %s requires an argument", printFlagNames(f)))
-					}
-				} else if !f.WasHit() && f.HasArgument() && f.Argument().HasDefault() {
-					if err := f.Argument().setToDefault(); err != nil {
-						errs.AppendError(err)
+						errs.AppendError(newMissingRequiredFlagArgumentError(f.Argument(), f, nil))
 					}
+				} else if !f.WasHit() && f.HasArgument() {
+					setFlagDefaults(f, errs)
 				}
 			}
 		}
 
 		current = current.Parent()
 	}
+
+	// Constraints are checked only once every node's flags have been resolved,
+	// as flags may reference flags inherited from parent nodes.
+	for current = selected; current != nil; current = current.Parent() {
+		checkFlagConstraints(current, current.FlagGroups(), errs)
+	}
 }
 
 func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unmapped *[]string) error {
@@ -234,12 +347,14 @@ func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unma
 		b := remainder[0]
 
 		// Look up the flag in the short flag map
-		f := c.current.FindShortFlag(b)
+		f := c.useFlag(c.current.FindShortFlag(b), false)
 
 		// If the flag was not found, append the arg to the unmapped slice and move
 		// on to the next character.
 		if f == nil {
-			c.tree.AppendWarning(fmt.Sprintf("unrecognized short flag -%c", b))
+			if err := c.unknownFlag(newUnknownFlagError(fmt.Sprintf("-%c", b), nil), element); err != nil {
+				return err
+			}
 			*unmapped = append(*unmapped, chars.StrDash+remainder[0:1])
 			remainder = remainder[1:]
 			continue
@@ -260,6 +375,7 @@ func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unma
 				// If the next element is literally the end of the cli args, then we
 				// obviously can't set an argument on this flag.  Tough luck, dude.
 				if nextElement.Type == parse.ElementTypeEnd {
+					c.awaiting = f
 					if hasBooleanArgument(f) {
 						return f.hitWithArg("true")
 					}
@@ -276,7 +392,7 @@ func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unma
 
 				// If we're here then we have a next element, and we're going to try and
 				// sacrifice it to the flag gods.
-				return f.hitWithArg(nextElement.String())
+				return c.hitWithArgs(f, nextElement.String())
 			}
 
 			if hasBooleanArgument(f) {
@@ -292,7 +408,7 @@ func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unma
 
 			// So we have at least one more character in this block.  Eat that and
 			// anything else as the flag argument.
-			return f.hitWithArg(remainder[1:])
+			return c.hitWithArgs(f, remainder[1:])
 		}
 
 		// If the flag doesn't _require_ an argument, but may take an optional
@@ -351,7 +467,7 @@ func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unma
 					return f.hit()
 
 				case parse.ElementTypeShortBlockSolo:
-					if c.current.FindShortFlag(nextElement.Data[0][0]) != nil {
+					if !isNegativeNumberValue(&nextElement, f.Argument(), c.current) && c.current.FindShortFlag(nextElement.Data[0][0]) != nil {
 						c.queue.Offer(nextElement)
 						return f.hit()
 					} else {
@@ -375,7 +491,7 @@ func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unma
 					}
 
 				case parse.ElementTypeLongFlagPair:
-					if c.current.FindLongFlag(nextElement.Data[0]) != nil {
+					if hasLongFlag(c.current, nextElement.Data[0]) {
 						c.queue.Offer(nextElement)
 						return f.hit()
 					} else {
@@ -387,7 +503,7 @@ func (c *commandTreeInterpreter) interpretShortSolo(element *parse.Element, unma
 					}
 
 				case parse.ElementTypeLongFlagSolo:
-					if c.current.FindLongFlag(nextElement.Data[0]) != nil {
+					if hasLongFlag(c.current, nextElement.Data[0]) {
 						c.queue.Offer(nextElement)
 						return f.hit()
 					} else {
@@ -424,7 +540,9 @@ func (c *commandTreeInterpreter) interpretShortPair(element *parse.Element, unma
 	block := element.Data[0]
 
 	if len(block) == 0 {
-		c.tree.AppendWarning("blank short flag name")
+		if err := c.unknownFlag(newUnknownFlagError(chars.StrDash, nil), element); err != nil {
+			return err
+		}
 		*unmapped = append(*unmapped, element.String())
 		return nil
 	}
@@ -432,10 +550,13 @@ func (c *commandTreeInterpreter) interpretShortPair(element *parse.Element, unma
 	// If the flag key block is a single character in length, then we can do this
 	// in a simple check.
 	if len(block) == 1 {
-		if f := c.current.FindShortFlag(block[0]); f != nil {
+		if f := c.useFlag(c.current.FindShortFlag(block[0]), false); f != nil {
 			c.flagHits.append(f)
-			return f.hitWithArg(element.Data[1])
+			return c.hitWithArgs(f, element.Data[1])
 		} else {
+			if err := c.unknownFlag(newUnknownFlagError(chars.StrDash+block, nil), element); err != nil {
+				return err
+			}
 			*unmapped = append(*unmapped, element.String())
 			return nil
 		}
@@ -447,10 +568,12 @@ func (c *commandTreeInterpreter) interpretShortPair(element *parse.Element, unma
 		// current character
 		b := block[0]
 
-		f := c.current.FindShortFlag(b)
+		f := c.useFlag(c.current.FindShortFlag(b), false)
 
 		if f == nil {
-			c.tree.AppendWarning(fmt.Sprintf("unrecognized short flag -%c", b))
+			if err := c.unknownFlag(newUnknownFlagError(fmt.Sprintf("-%c", b), nil), element); err != nil {
+				return err
+			}
 			*unmapped = append(*unmapped, chars.StrDash+block[0:1])
 			block = block[1:]
 			continue
@@ -460,9 +583,9 @@ func (c *commandTreeInterpreter) interpretShortPair(element *parse.Element, unma
 
 		if f.RequiresArgument() {
 			if h {
-				return f.hitWithArg(block[1:] + "=" + element.Data[1])
+				return c.hitWithArgs(f, block[1:]+"="+element.Data[1])
 			} else {
-				return f.hitWithArg(element.Data[1])
+				return c.hitWithArgs(f, element.Data[1])
 			}
 		}
 
@@ -511,10 +634,22 @@ func (c *commandTreeInterpreter) interpretShortPair(element *parse.Element, unma
 }
 
 func (c *commandTreeInterpreter) interpretLongSolo(element *parse.Element, unmapped *[]string) error {
-	f := c.current.FindLongFlag(element.Data[0])
+	f, err := c.current.resolveLongFlag(element.Data[0])
+	if err != nil {
+		return err
+	}
+
+	f = c.useFlag(f, false)
 
 	if f == nil {
-		c.tree.AppendWarning(fmt.Sprintf("unrecognized long flag --%s", element.Data[0]))
+		if f = c.useFlag(findNegatedLongFlag(c.current, element.Data[0]), true); f != nil {
+			c.flagHits.append(f)
+			return f.negate()
+		}
+
+		if err := c.unknownFlag(newUnknownLongFlagError(nodeFlagGroups(c.current), element.Data[0], c.tree.suggestionThreshold()), element); err != nil {
+			return err
+		}
 		*unmapped = append(*unmapped, element.String())
 		return nil
 	}
@@ -525,6 +660,7 @@ func (c *commandTreeInterpreter) interpretLongSolo(element *parse.Element, unmap
 		nextElement := c.next()
 
 		if nextElement.Type == parse.ElementTypeEnd {
+			c.awaiting = f
 			return f.hit()
 		}
 
@@ -533,7 +669,7 @@ func (c *commandTreeInterpreter) interpretLongSolo(element *parse.Element, unmap
 			return f.hit()
 		}
 
-		return f.hitWithArg(nextElement.String())
+		return c.hitWithArgs(f, nextElement.String())
 	}
 
 	if f.HasArgument() {
@@ -556,7 +692,7 @@ func (c *commandTreeInterpreter) interpretLongSolo(element *parse.Element, unmap
 			return f.hit()
 
 		case parse.ElementTypeLongFlagSolo:
-			if c.current.FindLongFlag(nextElement.Data[0]) != nil {
+			if hasLongFlag(c.current, nextElement.Data[0]) {
 				c.queue.Offer(nextElement)
 				return f.hit()
 			} else {
@@ -568,7 +704,7 @@ func (c *commandTreeInterpreter) interpretLongSolo(element *parse.Element, unmap
 			}
 
 		case parse.ElementTypeLongFlagPair:
-			if c.current.FindLongFlag(nextElement.Data[0]) != nil {
+			if hasLongFlag(c.current, nextElement.Data[0]) {
 				c.queue.Offer(nextElement)
 				return f.hit()
 			} else {
@@ -580,7 +716,7 @@ func (c *commandTreeInterpreter) interpretLongSolo(element *parse.Element, unmap
 			}
 
 		case parse.ElementTypeShortBlockSolo:
-			if len(nextElement.Data[0]) > 0 && c.current.FindShortFlag(nextElement.Data[0][0]) != nil {
+			if len(nextElement.Data[0]) > 0 && !isNegativeNumberValue(&nextElement, f.Argument(), c.current) && c.current.FindShortFlag(nextElement.Data[0][0]) != nil {
 				c.queue.Offer(nextElement)
 				return f.hit()
 			} else {
@@ -612,16 +748,29 @@ func (c *commandTreeInterpreter) interpretLongSolo(element *parse.Element, unmap
 }
 
 func (c *commandTreeInterpreter) interpretLongPair(element *parse.Element, unmapped *[]string) error {
-	flag := c.current.FindLongFlag(element.Data[0])
+	flag, err := c.current.resolveLongFlag(element.Data[0])
+	if err != nil {
+		return err
+	}
+
+	flag = c.useFlag(flag, false)
 
 	if flag == nil {
-		c.tree.AppendWarning(fmt.Sprintf("unrecognized long flag --%s", element.Data[0]))
+		if flag = c.useFlag(findNegatedLongFlag(c.current, element.Data[0]), true); flag != nil {
+			c.tree.AppendWarning(fmt.Sprintf("flag --%s received an argument it didn't expect", element.Data[0]))
+			c.flagHits.append(flag)
+			return flag.negate()
+		}
+
+		if err := c.unknownFlag(newUnknownLongFlagError(nodeFlagGroups(c.current), element.Data[0], c.tree.suggestionThreshold()), element); err != nil {
+			return err
+		}
 		*unmapped = append(*unmapped, element.String())
 	} else {
 		c.flagHits.append(flag)
 
 		if flag.HasArgument() {
-			return flag.hitWithArg(element.Data[1])
+			return c.hitWithArgs(flag, element.Data[1])
 		} else {
 			c.tree.AppendWarning(fmt.Sprintf("flag --%s received an argument it didn't expect", element.Data[0]))
 			return flag.hit()
@@ -631,52 +780,50 @@ func (c *commandTreeInterpreter) interpretLongPair(element *parse.Element, unmap
 	return nil
 }
 
-func (c *commandTreeInterpreter) invalidSubCommand(input string) error {
-	type pair struct {
-		depth int
-		child string
+// hitWithArgs hits the given flag with the given raw argument value.
+//
+// If the given flag takes multiple arguments, the values for its remaining
+// arguments are taken from the plain-text elements following the flag.  If
+// there are not enough of those elements, an error is returned.
+func (c *commandTreeInterpreter) hitWithArgs(f Flag, rawArg string) error {
+	arity := len(f.Arguments())
+
+	if arity < 2 {
+		return f.hitWithArg(rawArg)
 	}
 
-	matches := make([]pair, 0, 8)
+	values := make([]string, 1, arity)
+	values[0] = rawArg
 
-	if parent, ok := c.current.(CommandParent); ok {
-		for _, group := range parent.CommandGroups() {
-			for _, child := range group.Branches() {
-				if idx := strings.Index(child.Name(), input); idx > -1 {
-					matches = append(matches, pair{idx, child.Name()})
-				} else {
-					for _, alias := range child.Aliases() {
-						if idx := strings.Index(alias, input); idx > -1 {
-							matches = append(matches, pair{idx, alias})
-						}
-					}
-				}
-			}
+	for len(values) < arity {
+		nextElement := c.next()
 
-			for _, child := range group.Leaves() {
-				if idx := strings.Index(child.Name(), input); idx > -1 {
-					matches = append(matches, pair{idx, child.Name()})
-				} else {
-					for _, alias := range child.Aliases() {
-						if idx := strings.Index(alias, input); idx > -1 {
-							matches = append(matches, pair{idx, alias})
-						}
-					}
-				}
+		if nextElement.Type != parse.ElementTypePlainText && !isNegativeNumberValue(&nextElement, f.Arguments()[len(values)], c.current) {
+			if nextElement.Type == parse.ElementTypeEnd {
+				c.awaiting = f
 			}
+
+			c.queue.Offer(nextElement)
+			return newFlagArityError(f, len(values))
 		}
+
+		values = append(values, nextElement.String())
 	}
 
-	sort.Slice(matches, func(i, j int) bool {
-		return matches[i].depth < matches[j].depth || matches[i].child < matches[j].child
-	})
+	return f.hitWithArgs(values)
+}
+
+func (c *commandTreeInterpreter) invalidSubCommand(input string) error {
+	var matches []string
+
+	if parent, ok := c.current.(CommandParent); ok {
+		matches = suggestChildren(parent, input, c.tree.suggestionThreshold())
+	}
 
 	msg := new(bytes.Buffer)
 	buf := bufio.NewWriter(msg)
 
-	// TODO: Instead of using `tree.Name()` here print out the full path to the
-	//       current subcommand.  So like `app foo bar: Subcommand "f" is blah..."
-	util.MustReturn(buf.WriteString(fmt.Sprintf("%s: Subcommand \"%s\" is unrecognized.", c.tree.Name(), input)))
+	util.MustReturn(buf.WriteString(fmt.Sprintf("%s: Subcommand \"%s\" is unrecognized.", strings.Join(commandPath(c.current), " "), input)))
 	t := 0
 	if c.current.FindShortFlag('h') != nil {
 		t = 1
@@ -702,7 +849,7 @@ func (c *commandTreeInterpreter) invalidSubCommand(input string) error {
 
 		for i := range matches {
 			util.MustReturn(buf.WriteString("    "))
-			util.MustReturn(buf.WriteString(matches[i].child))
+			util.MustReturn(buf.WriteString(matches[i]))
 			util.MustReturn(buf.WriteString("\n"))
 		}
 	} else {
@@ -711,6 +858,17 @@ func (c *commandTreeInterpreter) invalidSubCommand(input string) error {
 
 	util.Must(buf.Flush())
 
-	//goland:noinspection GoUnreachableCode
-	return fmt.Errorf(msg.String())
+	return newUnknownSubcommandError(input, matches, msg.String())
+}
+
+// useFlag records a warning if the given flag is deprecated, and returns the
+// flag that should be hit in its place.
+func (c *commandTreeInterpreter) useFlag(flag Flag, negated bool) Flag {
+	if flag == nil || !flag.IsDeprecated() {
+		return flag
+	}
+
+	c.tree.AppendWarning(flagDeprecationWarning(flag))
+
+	return replacementFlag(c.current, flag, negated)
 }
@@ -1,6 +1,7 @@
 package argo_test
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -923,3 +924,544 @@ func TestRegression62CommandTree(t *testing.T) {
 		t.Error("expected value to be 23 but was", value)
 	}
 }
+
+// Inherited flag and leaf argument env vars
+func TestTreeInterpreterEnvVar01(t *testing.T) {
+	var flagValue int
+	var argValue string
+
+	t.Setenv("ARGO_TEST_FLAG", "12")
+	t.Setenv("ARGO_TEST_ARG", "hello")
+
+	_, err := cli.Tree().
+		WithFlag(cli.LongFlag("value").
+			WithBinding(&flagValue, true).
+			WithEnvVar("ARGO_TEST_FLAG")).
+		WithLeaf(cli.Leaf("leaf").
+			WithArgument(cli.Argument().
+				WithBinding(&argValue).
+				WithDefault("goodbye").
+				WithEnvVar("ARGO_TEST_ARG"))).
+		Parse([]string{"command", "leaf"})
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+
+	if flagValue != 12 {
+		t.Error("expected flag value to be 12 but was", flagValue)
+	}
+
+	if argValue != "hello" {
+		t.Error("expected arg value to be hello but was", argValue)
+	}
+}
+
+// Leaf flag requires a flag inherited from the tree
+func TestTreeInterpreterConstraint01(t *testing.T) {
+	tree := func() argo.CommandTreeBuilder {
+		return cli.Tree().
+			WithFlag(cli.LongFlag("key")).
+			WithLeaf(cli.Leaf("leaf").
+				WithFlag(cli.LongFlag("cert").RequiresFlag("key")))
+	}
+
+	if _, err := tree().Parse([]string{"command", "leaf", "--cert"}); err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+
+	if _, err := tree().Parse([]string{"command", "--key", "leaf", "--cert"}); err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+}
+
+// Leaf flag requires an inherited flag supplied by its environment variable
+func TestTreeInterpreterConstraint02(t *testing.T) {
+	var key string
+
+	t.Setenv("ARGO_TEST_KEY", "key.pem")
+
+	_, err := cli.Tree().
+		WithFlag(cli.LongFlag("key").
+			WithBinding(&key, true).
+			WithEnvVar("ARGO_TEST_KEY")).
+		WithLeaf(cli.Leaf("leaf").
+			WithFlag(cli.LongFlag("cert").RequiresFlag("key"))).
+		Parse([]string{"command", "leaf", "--cert"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if key != "key.pem" {
+		t.Error("expected key to be key.pem but was", key)
+	}
+}
+
+// Variadic leaf argument with a min count
+func TestTreeInterpreterVariadic01(t *testing.T) {
+	var files []string
+
+	_, err := cli.Tree().
+		WithLeaf(cli.Leaf("rm").
+			WithArgument(cli.Argument().
+				WithBinding(&files).
+				WithMinCount(1).
+				Variadic())).
+		Parse([]string{"command", "rm", "a", "b", "c"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(files) != 3 {
+		t.Error("expected 3 files but got", files)
+	}
+}
+
+func TestTreeInterpreterNegatable01(t *testing.T) {
+	var color bool
+	var level string
+
+	tree, err := cli.Tree().
+		WithFlag(cli.LongFlag("color").
+			WithBindingAndDefault(&color, true, false).
+			Negatable()).
+		WithLeaf(cli.Leaf("leaf").
+			WithFlag(cli.LongFlag("level").
+				WithBindingAndDefault(&level, "info", false))).
+		Parse([]string{"command", "leaf", "--level", "--no-color"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if color {
+		t.Error("expected color to be false but was true")
+	}
+
+	if level := tree.SelectedCommand().FindLongFlag("level"); level.HitCount() != 1 {
+		t.Error("expected level flag to be hit once but was hit", level.HitCount(), "times")
+	}
+}
+
+func TestTreeInterpreterAbbreviation01(t *testing.T) {
+	var dryRun bool
+
+	tree, err := cli.Tree().
+		WithAbbreviations().
+		WithFlag(cli.LongFlag("dry-run").WithBinding(&dryRun, false)).
+		WithBranch(cli.Branch("service").
+			WithAliases("svc").
+			WithLeaf(cli.Leaf("status")).
+			WithLeaf(cli.Leaf("stop"))).
+		Parse([]string{"command", "sv", "stat", "--dry"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if tree.SelectedCommand().Name() != "status" {
+		t.Error("expected status leaf to be selected but was", tree.SelectedCommand().Name())
+	}
+
+	if !dryRun {
+		t.Error("expected inherited dry-run flag to be set by its prefix")
+	}
+}
+
+func TestTreeInterpreterAbbreviation02(t *testing.T) {
+	_, err := cli.Tree().
+		WithAbbreviations().
+		WithLeaf(cli.Leaf("status")).
+		WithLeaf(cli.Leaf("stop")).
+		Parse([]string{"command", "st"})
+
+	var ape argo.AmbiguousPrefixError
+	if !errors.As(err, &ape) {
+		t.Fatal("expected err to be an AmbiguousPrefixError but was", err)
+	}
+
+	if ape.IsFlag() || len(ape.Candidates()) != 2 {
+		t.Error("expected 2 subcommand candidates but was", ape.Candidates())
+	}
+}
+
+// Hidden subcommands are not matched by prefix, but are matched by their full
+// name
+func TestTreeInterpreterAbbreviation03(t *testing.T) {
+	builder := cli.Tree().
+		WithAbbreviations().
+		WithLeaf(cli.Leaf("remove")).
+		WithLeaf(cli.Leaf("removal").Hidden())
+
+	tree, err := builder.Parse([]string{"command", "remo"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if tree.SelectedCommand().Name() != "remove" {
+		t.Error("expected remove leaf to be selected but was", tree.SelectedCommand().Name())
+	}
+
+	tree, err = builder.Parse([]string{"command", "removal"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if tree.SelectedCommand().Name() != "removal" {
+		t.Error("expected removal leaf to be selected but was", tree.SelectedCommand().Name())
+	}
+}
+
+func TestTreeInterpreterCounter01(t *testing.T) {
+	var level int
+	var force bool
+
+	_, err := cli.Tree().
+		WithFlag(cli.ShortFlag('v').WithCounter(&level)).
+		WithLeaf(cli.Leaf("leaf").
+			WithFlag(cli.ShortFlag('f').WithBinding(&force, false))).
+		Parse([]string{"command", "leaf", "-vfv"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if level != 2 {
+		t.Error("expected level to be 2 but was", level)
+	}
+
+	if !force {
+		t.Error("expected force to be true but was false")
+	}
+}
+
+func TestTreeInterpreterMultiArgument01(t *testing.T) {
+	var point [2]int
+
+	tree, err := cli.Tree().
+		WithFlag(cli.LongFlag("point").
+			WithArguments(
+				cli.Argument().WithBinding(&point[0]),
+				cli.Argument().WithBinding(&point[1]))).
+		WithLeaf(cli.Leaf("leaf").
+			WithArgument(cli.Argument().WithName("file"))).
+		Parse([]string{"command", "leaf", "--point", "3", "4", "file.txt"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if point != [2]int{3, 4} {
+		t.Error("expected point to be [3 4] but was", point)
+	}
+
+	if arg := tree.SelectedCommand().Arguments()[0]; arg.RawValue() != "file.txt" {
+		t.Error("expected file argument to be file.txt but was", arg.RawValue())
+	}
+}
+
+// Defaults for every argument of an inherited flag are applied when the flag is
+// absent
+func TestTreeInterpreterMultiArgument02(t *testing.T) {
+	var x, y int
+
+	_, err := cli.Tree().
+		WithFlag(cli.LongFlag("point").
+			WithArguments(
+				cli.Argument().WithBinding(&x).WithDefault(1),
+				cli.Argument().WithBinding(&y).WithDefault(2))).
+		WithLeaf(cli.Leaf("draw")).
+		Parse([]string{"command", "draw"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if x != 1 || y != 2 {
+		t.Errorf("expected point to be 1,2 but was %d,%d", x, y)
+	}
+}
+
+func TestTreeInterpreterInterspersed01(t *testing.T) {
+	var verbose bool
+
+	tree, err := cli.Tree().
+		WithFlag(cli.ShortFlag('v').WithBinding(&verbose, false)).
+		WithLeaf(cli.Leaf("exec").
+			WithInterspersedDisabled().
+			WithArgument(cli.Argument().WithName("command"))).
+		Parse([]string{"command", "exec", "-v", "ls", "-v", "--color"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !verbose {
+		t.Error("expected verbose to be true but was false")
+	}
+
+	leaf := tree.SelectedCommand()
+
+	if arg := leaf.Arguments()[0]; arg.RawValue() != "ls" {
+		t.Error("expected command argument to be ls but was", arg.RawValue())
+	}
+
+	expected := []string{"-v", "--color"}
+	if fmt.Sprint(leaf.UnmappedInputs()) != fmt.Sprint(expected) {
+		t.Error("expected unmapped inputs to be", expected, "but was", leaf.UnmappedInputs())
+	}
+}
+
+func TestTreeInterpreterNegativeNumber01(t *testing.T) {
+	var offset, value int
+
+	_, err := cli.Tree().
+		WithFlag(cli.LongFlag("offset").WithBinding(&offset, false)).
+		WithLeaf(cli.Leaf("leaf").
+			WithArgument(cli.Argument().WithName("value").WithBinding(&value))).
+		Parse([]string{"command", "--offset", "-2", "leaf", "-10"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if offset != -2 {
+		t.Error("expected offset to be -2 but was", offset)
+	}
+
+	if value != -10 {
+		t.Error("expected value to be -10 but was", value)
+	}
+}
+
+func TestTreeInterpreterSuggestion01(t *testing.T) {
+	_, err := cli.Tree().
+		WithLeaf(cli.Leaf("status")).
+		WithLeaf(cli.Leaf("stats")).
+		WithLeaf(cli.Leaf("commit")).
+		Parse([]string{"command", "stauts"})
+
+	var unknown argo.UnknownSubcommandError
+	if !errors.As(err, &unknown) {
+		t.Fatal("expected err to be an UnknownSubcommandError but was", err)
+	}
+
+	if unknown.Input() != "stauts" {
+		t.Error("expected input to be stauts but was", unknown.Input())
+	}
+
+	if fmt.Sprint(unknown.Suggestions()) != "[stats status]" {
+		t.Error("expected suggestions to be [stats status] but was", unknown.Suggestions())
+	}
+
+	if !strings.Contains(err.Error(), "Perhaps you meant one of:\n    stats\n    status\n") {
+		t.Error("expected error message to list suggestions but was", err.Error())
+	}
+}
+
+// Inherited long flags and aliases
+func TestTreeInterpreterSuggestion02(t *testing.T) {
+	var color bool
+
+	tree, err := cli.Tree().
+		WithFlag(cli.LongFlag("color").WithBinding(&color, false)).
+		WithLeaf(cli.Leaf("remove").WithAliases("rm")).
+		Parse([]string{"command", "rm", "--colr"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	warnings := tree.Warnings()
+	if len(warnings) != 1 || warnings[0] != "unrecognized long flag --colr; did you mean --color?" {
+		t.Error("expected a single warning suggesting --color but got", warnings)
+	}
+
+	_, err = cli.Tree().
+		WithLeaf(cli.Leaf("remove").WithAliases("rm")).
+		Parse([]string{"command", "rn"})
+
+	var unknown argo.UnknownSubcommandError
+	if !errors.As(err, &unknown) || fmt.Sprint(unknown.Suggestions()) != "[rm]" {
+		t.Error("expected the rm alias to be suggested but got", err)
+	}
+}
+
+// Unknown subcommand under a branch
+func TestTreeInterpreterParseError01(t *testing.T) {
+	_, err := cli.Tree().
+		WithRuntime(&argo.Runtime{ProgramName: "command"}).
+		WithBranch(cli.Branch("remote").
+			WithLeaf(cli.Leaf("add")).
+			WithLeaf(cli.Leaf("remove"))).
+		Parse([]string{"command", "remote", "ad"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorUnknownSubcommand {
+		t.Error("expected error code to be unknown-subcommand but was", parseErr.Code())
+	}
+
+	if fmt.Sprint(parseErr.CommandPath()) != "[command remote]" {
+		t.Error("expected command path to be [command remote] but was", parseErr.CommandPath())
+	}
+
+	if parseErr.Position() != 2 || parseErr.Token() != "ad" {
+		t.Errorf("expected error to be at position 2 (ad) but was %d (%s)", parseErr.Position(), parseErr.Token())
+	}
+
+	if !strings.HasPrefix(err.Error(), "command remote: Subcommand \"ad\" is unrecognized.") {
+		t.Error("expected error message to include the command path but was", err.Error())
+	}
+}
+
+// Unknown flag warning and missing leaf argument
+func TestTreeInterpreterParseError02(t *testing.T) {
+	ctx := new(argo.WarningContext)
+	tree, err := cli.Tree().
+		WithRuntime(&argo.Runtime{ProgramName: "command"}).
+		WithLeaf(cli.Leaf("build").
+			WithArgument(cli.Argument().WithName("target").Require())).
+		Build(ctx)
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	err = argo.ParseCommandTree(tree, []string{"command", "build", "--fast"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorMissingArgument {
+		t.Error("expected error code to be missing-argument but was", parseErr.Code())
+	}
+
+	if fmt.Sprint(parseErr.CommandPath()) != "[command build]" {
+		t.Error("expected command path to be [command build] but was", parseErr.CommandPath())
+	}
+
+	if len(ctx.GetWarningErrors()) != 1 || !errors.As(ctx.GetWarningErrors()[0], &parseErr) {
+		t.Fatal("expected a single ParseError warning but got", ctx.GetWarningErrors())
+	}
+
+	if parseErr.Code() != argo.ParseErrorUnknownFlag || parseErr.Position() != 2 || parseErr.Token() != "--fast" {
+		t.Errorf("expected unknown-flag warning at position 2 (--fast) but was %s at %d (%s)", parseErr.Code(), parseErr.Position(), parseErr.Token())
+	}
+}
+
+// Strict tree unknown short flag
+func TestTreeInterpreterStrict01(t *testing.T) {
+	_, err := cli.Tree().
+		WithRuntime(&argo.Runtime{ProgramName: "command"}).
+		WithStrictParsing().
+		WithLeaf(cli.Leaf("build")).
+		Parse([]string{"command", "build", "-x"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorUnknownFlag || parseErr.Token() != "-x" {
+		t.Errorf("expected unknown-flag error for -x but was %s for %s", parseErr.Code(), parseErr.Token())
+	}
+
+	if fmt.Sprint(parseErr.CommandPath()) != "[command build]" {
+		t.Error("expected command path to be [command build] but was", parseErr.CommandPath())
+	}
+}
+
+// Strict leaf stray positional argument
+func TestTreeInterpreterStrict02(t *testing.T) {
+	builder := cli.Tree().
+		WithLeaf(cli.Leaf("strict").WithStrictParsing()).
+		WithLeaf(cli.Leaf("loose"))
+
+	_, err := builder.Parse([]string{"command", "strict", "extra"})
+
+	var tooMany argo.TooManyArgumentsError
+	if !errors.As(err, &tooMany) || tooMany.Value() != "extra" {
+		t.Error("expected err to be a TooManyArgumentsError for extra but was", err)
+	}
+
+	tree, err := builder.Parse([]string{"command", "loose", "extra", "--unknown"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if fmt.Sprint(tree.SelectedCommand().UnmappedInputs()) != "[extra --unknown]" {
+		t.Error("expected unmapped inputs to be [extra --unknown] but was", tree.SelectedCommand().UnmappedInputs())
+	}
+}
+
+// Strict leaf unknown short flag with an attached value
+func TestTreeInterpreterStrict03(t *testing.T) {
+	_, err := cli.Tree().
+		WithLeaf(cli.Leaf("build").WithStrictParsing()).
+		Parse([]string{"command", "build", "-x=foo"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorUnknownFlag || parseErr.Token() != "-x=foo" {
+		t.Errorf("expected unknown-flag error for -x=foo but was %s for %s", parseErr.Code(), parseErr.Token())
+	}
+}
+
+// Deprecated subcommand replaced by another subcommand
+func TestTreeInterpreterDeprecated01(t *testing.T) {
+	tree, err := cli.Tree().
+		WithLeaf(cli.Leaf("remove").WithFlag(cli.LongFlag("force"))).
+		WithLeaf(cli.Leaf("rm").Hidden().Deprecated("").ReplacedBy("remove")).
+		Parse([]string{"command", "rm", "--force"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if tree.SelectedCommand().Name() != "remove" {
+		t.Error("expected selected command to be remove but was", tree.SelectedCommand().Name())
+	}
+
+	if !tree.SelectedCommand().FindLongFlag("force").WasHit() {
+		t.Error("expected --force to have been hit")
+	}
+
+	if fmt.Sprint(tree.Warnings()) != "[subcommand \"rm\" is deprecated; use remove instead]" {
+		t.Error("expected a deprecation warning but was", tree.Warnings())
+	}
+}
+
+// Deprecated flag inherited from a parent node
+func TestTreeInterpreterDeprecated02(t *testing.T) {
+	tree, err := cli.Tree().
+		WithFlag(cli.LongFlag("output").WithArgument(cli.Argument())).
+		WithFlag(cli.ShortFlag('o').WithArgument(cli.Argument()).Deprecated("").ReplacedBy("--output")).
+		WithLeaf(cli.Leaf("build")).
+		Parse([]string{"command", "build", "-o", "out.txt"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if flag := tree.FindLongFlag("output"); !flag.WasHit() || flag.Argument().RawValue() != "out.txt" {
+		t.Error("expected -o to be replaced by --output")
+	}
+
+	if len(tree.Warnings()) != 1 {
+		t.Error("expected 1 warning but got", tree.Warnings())
+	}
+}
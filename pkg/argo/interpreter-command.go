@@ -14,6 +14,14 @@ type commandInterpreter struct {
 	flagHits flagQueue
 	elements util.Deque[parse.Element]
 	boundary bool
+
+	// positional indicates that interspersed flags are disabled and a
+	// positional argument has been reached, making every remaining input a
+	// positional argument.
+	positional bool
+
+	// last is the most recently consumed element, not including the end marker.
+	last parse.Element
 }
 
 func (c *commandInterpreter) nextElement() parse.Element {
@@ -21,7 +29,32 @@ func (c *commandInterpreter) nextElement() parse.Element {
 		c.elements.Offer(c.parser.Next())
 	}
 
-	return c.elements.Poll()
+	next := c.elements.Poll()
+
+	if next.Type != parse.ElementTypeEnd {
+		c.last = next
+	}
+
+	return next
+}
+
+// parseError wraps the given error in a ParseError attributed to the given
+// element, if the error was caused by the CLI inputs.
+func (c *commandInterpreter) parseError(err error, element *parse.Element) error {
+	return toParseError(err, []string{c.command.Name()}, element, &c.last)
+}
+
+// unknownFlag records the given unknown flag error as a warning, or returns it
+// if strict parsing is enabled.
+func (c *commandInterpreter) unknownFlag(err error, element *parse.Element) error {
+	err = c.parseError(err, element)
+
+	if c.command.strictParsing() {
+		return err
+	}
+
+	c.command.appendWarningError(err)
+	return nil
 }
 
 func (c *commandInterpreter) Run() error {
@@ -42,31 +75,51 @@ FOR:
 			continue
 		}
 
+		if c.positional {
+			if element.Type == parse.ElementTypeEnd {
+				break
+			}
+
+			if err = c.command.appendArgument(element.String()); err != nil {
+				return c.parseError(err, &element)
+			}
+			continue
+		}
+
 		switch element.Type {
 
 		case parse.ElementTypePlainText:
 			if err = c.command.appendArgument(element.String()); err != nil {
-				return err
+				return c.parseError(err, &element)
 			}
 
+			c.positional = !c.command.allowsInterspersed()
+
 		case parse.ElementTypeShortBlockSolo:
-			if c.boundary, err = c.interpretShortSolo(&element); err != nil {
-				return err
+			// Negative numbers are positional argument values, not short flags.
+			if isNegativeNumberValue(&element, nextPositionalArgument(c.command.Arguments()), c.command) {
+				if err = c.command.appendArgument(element.String()); err != nil {
+					return c.parseError(err, &element)
+				}
+
+				c.positional = !c.command.allowsInterspersed()
+			} else if c.boundary, err = c.interpretShortSolo(&element); err != nil {
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeShortBlockPair:
 			if c.boundary, err = c.interpretShortPair(&element); err != nil {
-				return err
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeLongFlagSolo:
 			if c.boundary, err = c.interpretLongSolo(&element); err != nil {
-				return err
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeLongFlagPair:
 			if c.boundary, err = c.interpretLongPair(&element); err != nil {
-				return err
+				return c.parseError(err, &element)
 			}
 
 		case parse.ElementTypeBoundary:
@@ -84,6 +137,11 @@ FOR:
 
 	errs := newMultiError()
 
+	config, err := c.command.getConfigLoader().load(c.command)
+	if err != nil {
+		errs.AppendError(err)
+	}
+
 	flagGroups := c.command.FlagGroups()
 	for i := range flagGroups {
 		flagGroup := flagGroups[i].Flags()
@@ -91,6 +149,27 @@ FOR:
 		for j := range flagGroup {
 			f := flagGroup[j]
 
+			// If the flag was not used in the CLI call, attempt to fall back to its
+			// environment variable, then the config file, before applying the
+			// default.
+			if !f.WasHit() && f.HasArgument() {
+				if used, err := f.Argument().setToEnv(c.command.getRuntime()); err != nil {
+					errs.AppendError(err)
+					continue
+				} else if used {
+					f.markSupplied()
+					continue
+				}
+
+				if used, err := config.applyFlag(nil, f); err != nil {
+					errs.AppendError(err)
+					continue
+				} else if used {
+					f.markSupplied()
+					continue
+				}
+			}
+
 			if f.IsRequired() && !f.WasHit() {
 				errs.AppendError(newMissingFlagError(f))
 			}
@@ -99,20 +178,31 @@ FOR:
 				errs.AppendError(newMissingRequiredFlagArgumentError(f.Argument(), f, c.command))
 			}
 
-			if !f.WasHit() && f.HasArgument() && f.Argument().HasDefault() {
-				if err := f.Argument().setToDefault(); err != nil {
-					errs.AppendError(err)
-				}
+			if !f.WasHit() && f.HasArgument() {
+				setFlagDefaults(f, errs)
 			}
 		}
 	}
 
+	checkFlagConstraints(c.command, flagGroups, errs)
+
 	arguments := c.command.Arguments()
 	for i := range arguments {
 		arg := arguments[i]
 
+		if !arg.WasHit() {
+			if used, err := arg.setToEnv(c.command.getRuntime()); err != nil {
+				errs.AppendError(err)
+				continue
+			} else if used {
+				continue
+			}
+		}
+
 		if arg.IsRequired() && !arg.WasHit() {
 			errs.AppendError(newMissingRequiredPositionalArgumentError(arg, c.command))
+		} else if arg.WasHit() && len(arg.RawValues()) < arg.MinCount() {
+			errs.AppendError(newArgumentCountError(arg))
 		}
 		if !arg.WasHit() && arg.HasDefault() {
 			if err := arg.setToDefault(); err != nil {
@@ -121,28 +211,22 @@ FOR:
 		}
 	}
 
+	// If a help flag was used, it takes priority over all other callbacks.
 	var it = c.flagHits.iterator()
-	var hf = 0
 	for it.hasNext() {
-		flag := it.next()
-		if flag.isHelpFlag() {
+		if flag := it.next(); flag.isHelpFlag() {
 			flag.executeCallback()
-			break
+			return newExitError(0)
 		}
-		hf++
 	}
 
 	it = c.flagHits.iterator()
-	var cf = 0
 	for it.hasNext() {
-		if cf != hf {
-			it.next().executeCallback()
-		}
-		cf++
+		it.next().executeCallback()
 	}
 
 	if len(errs.Errors()) > 0 {
-		return errs
+		return toParseErrors(errs, []string{c.command.Name()})
 	}
 
 	c.command.executeCallback()
@@ -160,12 +244,14 @@ func (c *commandInterpreter) interpretShortSolo(e *parse.Element) (bool, error)
 		b := remainder[0]
 
 		// Look up the flag in the short flag map
-		f := c.command.FindShortFlag(b)
+		f := c.useFlag(c.command.FindShortFlag(b), false)
 
 		// If the flag was not found, append the arg to the unmapped slice and move
 		// on to the next character.
 		if f == nil {
-			c.command.AppendWarning(fmt.Sprintf("unrecognized short flag -%c", b))
+			if err := c.unknownFlag(newUnknownFlagError(fmt.Sprintf("-%c", b), nil), e); err != nil {
+				return false, err
+			}
 			c.command.appendUnmapped(chars.StrDash + remainder[0:1])
 			remainder = remainder[1:]
 			continue
@@ -201,7 +287,7 @@ func (c *commandInterpreter) interpretShortSolo(e *parse.Element) (bool, error)
 
 				// If we're here then we have a next element, and we're going to try and
 				// sacrifice it to the flag gods.
-				return false, f.hitWithArg(nextElement.String())
+				return c.hitWithArgs(f, nextElement.String())
 			}
 
 			if hasBooleanArgument(f) {
@@ -217,7 +303,7 @@ func (c *commandInterpreter) interpretShortSolo(e *parse.Element) (bool, error)
 
 			// So we have at least one more character in this block.  Eat that and
 			// anything else as the flag argument.
-			return false, f.hitWithArg(remainder[1:])
+			return c.hitWithArgs(f, remainder[1:])
 		}
 
 		// If the flag doesn't _require_ an argument, but may take an optional
@@ -275,7 +361,7 @@ func (c *commandInterpreter) interpretShortSolo(e *parse.Element) (bool, error)
 					return false, f.hit()
 
 				case parse.ElementTypeShortBlockSolo:
-					if c.command.FindShortFlag(nextElement.Data[0][0]) != nil {
+					if !isNegativeNumberValue(&nextElement, f.Argument(), c.command) && c.command.FindShortFlag(nextElement.Data[0][0]) != nil {
 						c.elements.Offer(nextElement)
 						return false, f.hit()
 					}
@@ -299,7 +385,7 @@ func (c *commandInterpreter) interpretShortSolo(e *parse.Element) (bool, error)
 					return false, f.hit()
 
 				case parse.ElementTypeLongFlagPair:
-					if c.command.FindLongFlag(nextElement.Data[0]) != nil {
+					if hasLongFlag(c.command, nextElement.Data[0]) {
 						c.elements.Offer(nextElement)
 						return false, f.hit()
 					}
@@ -311,7 +397,7 @@ func (c *commandInterpreter) interpretShortSolo(e *parse.Element) (bool, error)
 					return false, f.hit()
 
 				case parse.ElementTypeLongFlagSolo:
-					if c.command.FindLongFlag(nextElement.Data[0]) != nil {
+					if hasLongFlag(c.command, nextElement.Data[0]) {
 						c.elements.Offer(nextElement)
 						return false, f.hit()
 					}
@@ -345,6 +431,9 @@ func (c *commandInterpreter) interpretShortPair(e *parse.Element) (bool, error)
 	block := e.Data[0]
 
 	if len(block) == 0 {
+		if err := c.unknownFlag(newUnknownFlagError(chars.StrDash, nil), e); err != nil {
+			return false, err
+		}
 		c.command.appendUnmapped(e.String())
 		return false, nil
 	}
@@ -352,11 +441,14 @@ func (c *commandInterpreter) interpretShortPair(e *parse.Element) (bool, error)
 	// If the flag key block is a single character in length, then we can do this
 	// in a simple check.
 	if len(block) == 1 {
-		if f := c.command.FindShortFlag(block[0]); f != nil {
+		if f := c.useFlag(c.command.FindShortFlag(block[0]), false); f != nil {
 			c.flagHits.append(f)
-			return false, f.hitWithArg(e.Data[1])
+			return c.hitWithArgs(f, e.Data[1])
 		}
 
+		if err := c.unknownFlag(newUnknownFlagError(chars.StrDash+block, nil), e); err != nil {
+			return false, err
+		}
 		c.command.appendUnmapped(e.String())
 		return false, nil
 	}
@@ -367,10 +459,12 @@ func (c *commandInterpreter) interpretShortPair(e *parse.Element) (bool, error)
 		// current character
 		b := block[0]
 
-		f := c.command.FindShortFlag(b)
+		f := c.useFlag(c.command.FindShortFlag(b), false)
 
 		if f == nil {
-			c.command.AppendWarning(fmt.Sprintf("unrecognized short flag -%c", b))
+			if err := c.unknownFlag(newUnknownFlagError(fmt.Sprintf("-%c", b), nil), e); err != nil {
+				return false, err
+			}
 			c.command.appendUnmapped(chars.StrDash + block[0:1])
 			continue
 		}
@@ -379,10 +473,10 @@ func (c *commandInterpreter) interpretShortPair(e *parse.Element) (bool, error)
 
 		if f.RequiresArgument() {
 			if h {
-				return false, f.hitWithArg(block[1:] + "=" + e.Data[1])
+				return c.hitWithArgs(f, block[1:]+"="+e.Data[1])
 			}
 
-			return false, f.hitWithArg(e.Data[1])
+			return c.hitWithArgs(f, e.Data[1])
 		}
 
 		if f.HasArgument() {
@@ -418,10 +512,22 @@ func (c *commandInterpreter) interpretShortPair(e *parse.Element) (bool, error)
 }
 
 func (c *commandInterpreter) interpretLongSolo(e *parse.Element) (bool, error) {
-	f := c.command.FindLongFlag(e.Data[0])
+	f, err := c.command.resolveLongFlag(e.Data[0])
+	if err != nil {
+		return false, err
+	}
+
+	f = c.useFlag(f, false)
 
 	if f == nil {
-		c.command.AppendWarning(fmt.Sprintf("unrecognized long flag --%s", e.Data[0]))
+		if f = c.useFlag(findNegatedLongFlag(c.command, e.Data[0]), true); f != nil {
+			c.flagHits.append(f)
+			return false, f.negate()
+		}
+
+		if err := c.unknownFlag(newUnknownLongFlagError(c.command.FlagGroups(), e.Data[0], c.command.suggestionThreshold()), e); err != nil {
+			return false, err
+		}
 		c.command.appendUnmapped(e.String())
 		return false, nil
 	}
@@ -429,7 +535,7 @@ func (c *commandInterpreter) interpretLongSolo(e *parse.Element) (bool, error) {
 	c.flagHits.append(f)
 
 	if f.RequiresArgument() {
-		nextElement := c.parser.Next()
+		nextElement := c.nextElement()
 
 		if nextElement.Type == parse.ElementTypeEnd {
 			return false, f.hit()
@@ -439,7 +545,7 @@ func (c *commandInterpreter) interpretLongSolo(e *parse.Element) (bool, error) {
 			return true, f.hit()
 		}
 
-		return false, f.hitWithArg(nextElement.String())
+		return c.hitWithArgs(f, nextElement.String())
 	}
 
 	if f.HasArgument() {
@@ -461,7 +567,7 @@ func (c *commandInterpreter) interpretLongSolo(e *parse.Element) (bool, error) {
 			return false, f.hit()
 
 		case parse.ElementTypeLongFlagSolo:
-			if c.command.FindLongFlag(nextElement.Data[0]) != nil {
+			if hasLongFlag(c.command, nextElement.Data[0]) {
 				c.elements.Offer(nextElement)
 				return false, f.hit()
 			}
@@ -473,7 +579,7 @@ func (c *commandInterpreter) interpretLongSolo(e *parse.Element) (bool, error) {
 			return false, f.hit()
 
 		case parse.ElementTypeLongFlagPair:
-			if c.command.FindLongFlag(nextElement.Data[0]) != nil {
+			if hasLongFlag(c.command, nextElement.Data[0]) {
 				c.elements.Offer(nextElement)
 				return false, f.hit()
 			}
@@ -485,7 +591,7 @@ func (c *commandInterpreter) interpretLongSolo(e *parse.Element) (bool, error) {
 			return false, f.hit()
 
 		case parse.ElementTypeShortBlockSolo:
-			if len(nextElement.Data[0]) > 0 && c.command.FindShortFlag(nextElement.Data[0][0]) != nil {
+			if len(nextElement.Data[0]) > 0 && !isNegativeNumberValue(&nextElement, f.Argument(), c.command) && c.command.FindShortFlag(nextElement.Data[0][0]) != nil {
 				c.elements.Offer(nextElement)
 				return false, f.hit()
 			}
@@ -517,16 +623,29 @@ func (c *commandInterpreter) interpretLongSolo(e *parse.Element) (bool, error) {
 }
 
 func (c *commandInterpreter) interpretLongPair(e *parse.Element) (bool, error) {
-	flag := c.command.FindLongFlag(e.Data[0])
+	flag, err := c.command.resolveLongFlag(e.Data[0])
+	if err != nil {
+		return false, err
+	}
+
+	flag = c.useFlag(flag, false)
 
 	if flag == nil {
-		c.command.AppendWarning(fmt.Sprintf("unrecognized long flag --%s", e.Data[0]))
+		if flag = c.useFlag(findNegatedLongFlag(c.command, e.Data[0]), true); flag != nil {
+			c.command.AppendWarning(fmt.Sprintf("flag --%s received an argument it didn't expect", e.Data[0]))
+			c.flagHits.append(flag)
+			return false, flag.negate()
+		}
+
+		if err := c.unknownFlag(newUnknownLongFlagError(c.command.FlagGroups(), e.Data[0], c.command.suggestionThreshold()), e); err != nil {
+			return false, err
+		}
 		c.command.appendUnmapped(e.String())
 	} else {
 		c.flagHits.append(flag)
 
 		if flag.HasArgument() {
-			return false, flag.hitWithArg(e.Data[1])
+			return c.hitWithArgs(flag, e.Data[1])
 		}
 		c.command.AppendWarning(fmt.Sprintf("flag --%s received an argument it didn't expect", e.Data[0]))
 		return false, flag.hit()
@@ -534,3 +653,44 @@ func (c *commandInterpreter) interpretLongPair(e *parse.Element) (bool, error) {
 
 	return false, nil
 }
+
+// hitWithArgs hits the given flag with the given raw argument value.
+//
+// If the given flag takes multiple arguments, the values for its remaining
+// arguments are taken from the plain-text elements following the flag.  If
+// there are not enough of those elements, an error is returned.
+func (c *commandInterpreter) hitWithArgs(f Flag, rawArg string) (bool, error) {
+	arity := len(f.Arguments())
+
+	if arity < 2 {
+		return false, f.hitWithArg(rawArg)
+	}
+
+	values := make([]string, 1, arity)
+	values[0] = rawArg
+
+	for len(values) < arity {
+		nextElement := c.nextElement()
+
+		if nextElement.Type != parse.ElementTypePlainText && !isNegativeNumberValue(&nextElement, f.Arguments()[len(values)], c.command) {
+			c.elements.Offer(nextElement)
+			return false, newFlagArityError(f, len(values))
+		}
+
+		values = append(values, nextElement.String())
+	}
+
+	return false, f.hitWithArgs(values)
+}
+
+// useFlag records a warning if the given flag is deprecated, and returns the
+// flag that should be hit in its place.
+func (c *commandInterpreter) useFlag(flag Flag, negated bool) Flag {
+	if flag == nil || !flag.IsDeprecated() {
+		return flag
+	}
+
+	c.command.AppendWarning(flagDeprecationWarning(flag))
+
+	return replacementFlag(c.command, flag, negated)
+}
@@ -1,6 +1,7 @@
 package argo_test
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -493,3 +494,1224 @@ func TestRegression62Command(t *testing.T) {
 		t.Error("expected value to be 23 but was", value)
 	}
 }
+
+// Flag env var takes priority over default
+func TestCommandInterpreterEnvVar01(t *testing.T) {
+	var value int
+
+	t.Setenv("ARGO_TEST_VALUE", "12")
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("value").
+			WithBindingAndDefault(&value, 3, false).
+			WithEnvVar("ARGO_TEST_VALUE")).
+		Parse([]string{"command"})
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+
+	if value != 12 {
+		t.Error("expected value to be 12 but was", value)
+	}
+}
+
+// CLI input takes priority over flag env var
+func TestCommandInterpreterEnvVar02(t *testing.T) {
+	var value int
+
+	t.Setenv("ARGO_TEST_VALUE", "12")
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("value").
+			WithBindingAndDefault(&value, 3, false).
+			WithEnvVar("ARGO_TEST_VALUE")).
+		Parse([]string{"command", "--value=7"})
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+
+	if value != 7 {
+		t.Error("expected value to be 7 but was", value)
+	}
+}
+
+// Required positional argument satisfied by env var
+func TestCommandInterpreterEnvVar03(t *testing.T) {
+	var value string
+
+	t.Setenv("ARGO_TEST_VALUE", "hello")
+
+	_, err := cli.Command().
+		WithArgument(cli.Argument().
+			WithBinding(&value).
+			WithEnvVar("ARGO_TEST_VALUE").
+			Require()).
+		Parse([]string{"command"})
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+
+	if value != "hello" {
+		t.Error("expected value to be hello but was", value)
+	}
+}
+
+// Env var values are passed through argument validators
+func TestCommandInterpreterEnvVar04(t *testing.T) {
+	var value int
+
+	t.Setenv("ARGO_TEST_VALUE", "12")
+
+	_, err := cli.Command().
+		WithArgument(cli.Argument().
+			WithBinding(&value).
+			WithEnvVar("ARGO_TEST_VALUE").
+			WithValidator(argo.NumericRangePostParseArgumentValidator(0, 10, "out of range"))).
+		Parse([]string{"command"})
+
+	if err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
+
+// Mutually exclusive flags used together
+func TestCommandInterpreterConstraint01(t *testing.T) {
+	_, err := cli.Command().
+		WithFlagGroup(cli.FlagGroup("Output").
+			MutuallyExclusive().
+			WithFlag(cli.LongFlag("json")).
+			WithFlag(cli.LongFlag("yaml"))).
+		Parse([]string{"command", "--json", "--yaml"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.FlagConstraintError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be a FlagConstraintError but was", err)
+	}
+
+	if ce.Kind() != argo.FlagConstraintMutuallyExclusive {
+		t.Error("expected constraint kind to be mutually exclusive but was", ce.Kind())
+	}
+
+	if len(ce.Flags()) != 2 {
+		t.Error("expected constraint error to name 2 flags but it named", len(ce.Flags()))
+	}
+
+	if ce.Error() != "flags --json, --yaml may not be used together" {
+		t.Error("expected a different error message but was", ce.Error())
+	}
+}
+
+// Mutually exclusive flags used alone
+func TestCommandInterpreterConstraint02(t *testing.T) {
+	_, err := cli.Command().
+		WithFlagGroup(cli.FlagGroup("Output").
+			MutuallyExclusive().
+			WithFlag(cli.LongFlag("json")).
+			WithFlag(cli.LongFlag("yaml"))).
+		Parse([]string{"command", "--yaml"})
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+}
+
+// Exactly one flag group with no flags used
+func TestCommandInterpreterConstraint03(t *testing.T) {
+	_, err := cli.Command().
+		WithFlagGroup(cli.FlagGroup("Output").
+			ExactlyOne().
+			WithFlag(cli.LongFlag("json")).
+			WithFlag(cli.LongFlag("yaml"))).
+		Parse([]string{"command"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.FlagConstraintError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be a FlagConstraintError but was", err)
+	}
+
+	if ce.Kind() != argo.FlagConstraintExactlyOne {
+		t.Error("expected constraint kind to be exactly one but was", ce.Kind())
+	}
+
+	if ce.Error() != "exactly one of the flags --json, --yaml must be used" {
+		t.Error("expected a different error message but was", ce.Error())
+	}
+}
+
+// Flag used without the flag it requires
+func TestCommandInterpreterConstraint04(t *testing.T) {
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("cert").RequiresFlag("key")).
+		WithFlag(cli.ComboFlag('k', "key")).
+		Parse([]string{"command", "--cert"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.FlagConstraintError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be a FlagConstraintError but was", err)
+	}
+
+	if ce.Kind() != argo.FlagConstraintRequires {
+		t.Error("expected constraint kind to be requires but was", ce.Kind())
+	}
+
+	if ce.Error() != "flag --cert requires flag -k | --key" {
+		t.Error("expected a different error message but was", ce.Error())
+	}
+}
+
+// Flag used with the flag it requires
+func TestCommandInterpreterConstraint05(t *testing.T) {
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("cert").RequiresFlag("-k")).
+		WithFlag(cli.ComboFlag('k', "key")).
+		Parse([]string{"command", "--cert", "-k"})
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+}
+
+// Flag used with a flag it conflicts with
+func TestCommandInterpreterConstraint06(t *testing.T) {
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("quiet").ConflictsWith("--verbose")).
+		WithFlag(cli.LongFlag("verbose")).
+		Parse([]string{"command", "--verbose", "--quiet"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.FlagConstraintError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be a FlagConstraintError but was", err)
+	}
+
+	if ce.Kind() != argo.FlagConstraintConflicts {
+		t.Error("expected constraint kind to be conflicts but was", ce.Kind())
+	}
+}
+
+// Required flag supplied by its environment variable
+func TestCommandInterpreterConstraint07(t *testing.T) {
+	var key string
+
+	t.Setenv("ARGO_TEST_KEY", "key.pem")
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("cert").RequiresFlag("key")).
+		WithFlag(cli.LongFlag("key").
+			WithBinding(&key, true).
+			WithEnvVar("ARGO_TEST_KEY")).
+		Parse([]string{"command", "--cert"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if key != "key.pem" {
+		t.Error("expected key to be key.pem but was", key)
+	}
+}
+
+// Flag used with a conflicting flag supplied by its environment variable
+func TestCommandInterpreterConstraint08(t *testing.T) {
+	var verbose bool
+
+	t.Setenv("ARGO_TEST_VERBOSE", "true")
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("quiet").ConflictsWith("--verbose")).
+		WithFlag(cli.LongFlag("verbose").
+			WithBinding(&verbose, false).
+			WithEnvVar("ARGO_TEST_VERBOSE")).
+		Parse([]string{"command", "--quiet"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.FlagConstraintError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be a FlagConstraintError but was", err)
+	}
+
+	if ce.Kind() != argo.FlagConstraintConflicts {
+		t.Error("expected constraint kind to be conflicts but was", ce.Kind())
+	}
+}
+
+// Default values do not satisfy a required flag
+func TestCommandInterpreterConstraint09(t *testing.T) {
+	var key string
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("cert").RequiresFlag("key")).
+		WithFlag(cli.LongFlag("key").WithBindingAndDefault(&key, "key.pem", true)).
+		Parse([]string{"command", "--cert"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	if key != "key.pem" {
+		t.Error("expected key to be key.pem but was", key)
+	}
+}
+
+// Last positional argument bound to a slice consumes remaining values
+func TestCommandInterpreterVariadic01(t *testing.T) {
+	var name string
+	var files []string
+
+	com, err := cli.Command().
+		WithArgument(cli.Argument().WithBinding(&name)).
+		WithArgument(cli.Argument().WithBinding(&files)).
+		Parse([]string{"command", "rm", "a", "b", "c"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if name != "rm" {
+		t.Error("expected name to be rm but was", name)
+	}
+
+	if len(files) != 3 || files[0] != "a" || files[1] != "b" || files[2] != "c" {
+		t.Error("expected files to be [a b c] but was", files)
+	}
+
+	if com.HasUnmappedInputs() {
+		t.Error("expected command to have no unmapped inputs but had", com.UnmappedInputs())
+	}
+
+	if !com.Arguments()[1].IsVariadic() {
+		t.Error("expected slice bound argument to be variadic but it wasn't")
+	}
+}
+
+// Variadic argument with a consumer binding and a max count
+func TestCommandInterpreterVariadic02(t *testing.T) {
+	var values []int
+
+	com, err := cli.Command().
+		WithArgument(cli.Argument().
+			WithBinding(func(v int) { values = append(values, v) }).
+			Variadic().
+			WithMaxCount(2)).
+		Parse([]string{"command", "1", "2", "3"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Error("expected values to be [1 2] but was", values)
+	}
+
+	if len(com.UnmappedInputs()) != 1 || com.UnmappedInputs()[0] != "3" {
+		t.Error("expected unmapped inputs to be [3] but was", com.UnmappedInputs())
+	}
+}
+
+// Variadic argument given fewer values than its min count
+func TestCommandInterpreterVariadic03(t *testing.T) {
+	var files []string
+
+	_, err := cli.Command().
+		WithArgument(cli.Argument().
+			WithName("files").
+			WithBinding(&files).
+			Variadic().
+			WithMinCount(2)).
+		Parse([]string{"command", "a"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	var ce argo.ArgumentCountError
+	if !errors.As(err.(argo.MultiError).Errors()[0], &ce) {
+		t.Fatal("expected err to be an ArgumentCountError but was", err)
+	}
+
+	if ce.Count() != 1 {
+		t.Error("expected count to be 1 but was", ce.Count())
+	}
+}
+
+// Variadic argument values are validated individually
+func TestCommandInterpreterVariadic04(t *testing.T) {
+	var files []string
+
+	_, err := cli.Command().
+		WithArgument(cli.Argument().
+			WithBinding(&files).
+			WithValidator(func(raw string) error {
+				if raw == "bad" {
+					return errors.New("bad value")
+				}
+				return nil
+			})).
+		Parse([]string{"command", "a", "bad", "c"})
+
+	if err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
+
+// Negated flag form overrides a true environment value
+func TestCommandInterpreterNegatable01(t *testing.T) {
+	var color bool
+
+	t.Setenv("ARGO_TEST_COLOR", "true")
+
+	com, err := cli.Command().
+		WithFlag(cli.LongFlag("color").
+			WithBindingAndDefault(&color, true, false).
+			WithEnvVar("ARGO_TEST_COLOR").
+			Negatable()).
+		Parse([]string{"command", "--no-color"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if color {
+		t.Error("expected color to be false but was true")
+	}
+
+	if flag := com.FindLongFlag("color"); !flag.WasHit() || !flag.WasNegated() {
+		t.Error("expected color flag to have been hit in its negated form")
+	}
+}
+
+// Positive and negated forms, last use wins
+func TestCommandInterpreterNegatable02(t *testing.T) {
+	var color bool
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("color").
+			WithBinding(&color, false).
+			Negatable()).
+		Parse([]string{"command", "--no-color", "--color"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !color {
+		t.Error("expected color to be true but was false")
+	}
+}
+
+// Negated form of a flag that is not negatable is unrecognized
+func TestCommandInterpreterNegatable03(t *testing.T) {
+	var color bool
+
+	com, err := cli.Command().
+		WithFlag(cli.LongFlag("color").
+			WithBinding(&color, false)).
+		Parse([]string{"command", "--no-color"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(com.UnmappedInputs()) != 1 || com.UnmappedInputs()[0] != "--no-color" {
+		t.Error("expected --no-color to be unmapped but unmapped inputs were", com.UnmappedInputs())
+	}
+}
+
+// Negated form conflicting with another flag's long form
+func TestCommandInterpreterNegatable04(t *testing.T) {
+	var color bool
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("color").
+			WithBinding(&color, false).
+			Negatable()).
+		WithFlag(cli.LongFlag("no-color")).
+		Build(nil)
+
+	if err == nil {
+		t.Error("expected err not to be nil but it was")
+	}
+}
+
+// Unique long flag prefixes resolve when abbreviations are enabled
+func TestCommandInterpreterAbbreviation01(t *testing.T) {
+	var verbose bool
+
+	com, err := cli.Command().
+		WithAbbreviations().
+		WithFlag(cli.LongFlag("verbose").WithBinding(&verbose, false)).
+		WithFlag(cli.LongFlag("version")).
+		Parse([]string{"command", "--verb"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !verbose {
+		t.Error("expected verbose to be true but was false")
+	}
+
+	if com.FindLongFlag("vers") == nil || com.FindLongFlag("vers").LongForm() != "version" {
+		t.Error("expected FindLongFlag to resolve --vers to --version")
+	}
+}
+
+// Ambiguous long flag prefixes return an error listing the candidates
+func TestCommandInterpreterAbbreviation02(t *testing.T) {
+	_, err := cli.Command().
+		WithAbbreviations().
+		WithFlag(cli.LongFlag("verbose")).
+		WithFlag(cli.LongFlag("version")).
+		Parse([]string{"command", "--ver"})
+
+	var ape argo.AmbiguousPrefixError
+	if !errors.As(err, &ape) {
+		t.Fatal("expected err to be an AmbiguousPrefixError but was", err)
+	}
+
+	if !ape.IsFlag() || ape.Prefix() != "ver" {
+		t.Error("expected ambiguous flag prefix ver but was", ape.Prefix())
+	}
+
+	if len(ape.Candidates()) != 2 || ape.Candidates()[0] != "--verbose" || ape.Candidates()[1] != "--version" {
+		t.Error("expected candidates --verbose and --version but was", ape.Candidates())
+	}
+}
+
+// Long flag prefixes are not resolved unless abbreviations are enabled
+func TestCommandInterpreterAbbreviation03(t *testing.T) {
+	com, err := cli.Command().
+		WithFlag(cli.LongFlag("verbose")).
+		Parse([]string{"command", "--verb"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if com.FindLongFlag("verbose").WasHit() {
+		t.Error("expected verbose flag not to have been hit")
+	}
+
+	if len(com.UnmappedInputs()) != 1 {
+		t.Error("expected --verb to be unmapped but unmapped inputs were", com.UnmappedInputs())
+	}
+}
+
+// Hidden flags are not matched by prefix, but are matched by their full name
+func TestCommandInterpreterAbbreviation04(t *testing.T) {
+	builder := cli.Command().
+		WithAbbreviations().
+		WithFlag(cli.LongFlag("color")).
+		WithFlag(cli.LongFlag("colour").Hidden())
+
+	com, err := builder.Parse([]string{"command", "--colo"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !com.FindLongFlag("color").WasHit() || com.FindLongFlag("colour").WasHit() {
+		t.Error("expected --colo to resolve to --color")
+	}
+
+	com, err = builder.Parse([]string{"command", "--colour"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !com.FindLongFlag("colour").WasHit() {
+		t.Error("expected --colour to have been hit")
+	}
+}
+
+// Negated forms are not abbreviated
+func TestCommandInterpreterAbbreviation05(t *testing.T) {
+	var color bool
+
+	com, err := cli.Command().
+		WithAbbreviations().
+		WithFlag(cli.LongFlag("color").
+			WithBinding(&color, false).
+			Negatable()).
+		Parse([]string{"command", "--no-col"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if com.FindLongFlag("color").WasHit() {
+		t.Error("expected color flag to not have been hit")
+	}
+
+	if len(com.UnmappedInputs()) != 1 || com.UnmappedInputs()[0] != "--no-col" {
+		t.Error("expected --no-col to be unmapped but unmapped inputs were", com.UnmappedInputs())
+	}
+}
+
+func TestCommandInterpreterCounter01(t *testing.T) {
+	var level int
+
+	com, err := cli.Command().
+		WithFlag(cli.ShortFlag('v').WithCounter(&level)).
+		Parse([]string{"command", "-vvv", "-v"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if level != 4 {
+		t.Error("expected level to be 4 but was", level)
+	}
+
+	if flag := com.FindShortFlag('v'); !flag.IsCounter() || flag.HitCount() != 4 {
+		t.Error("expected v flag to be a counter hit 4 times")
+	}
+}
+
+// Counter max cap
+func TestCommandInterpreterCounter02(t *testing.T) {
+	var level int
+
+	_, err := cli.Command().
+		WithFlag(cli.Flag().
+			WithShortForm('v').
+			WithLongForm("verbose").
+			WithCounter(&level).
+			WithCounterMax(2)).
+		Parse([]string{"command", "-vv", "--verbose"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if level != 2 {
+		t.Error("expected level to be 2 but was", level)
+	}
+}
+
+// Paired decrement flag in a clustered block
+func TestCommandInterpreterCounter03(t *testing.T) {
+	level := 1
+
+	_, err := cli.Command().
+		WithFlag(cli.ShortFlag('v').WithCounter(&level)).
+		WithFlag(cli.ShortFlag('q').WithDecrementCounter(&level)).
+		Parse([]string{"command", "-vqq", "-q"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if level != 0 {
+		t.Error("expected level to be 0 but was", level)
+	}
+}
+
+// Decrement flag does not take the counter below zero
+func TestCommandInterpreterCounter04(t *testing.T) {
+	var level int
+
+	_, err := cli.Command().
+		WithFlag(cli.ShortFlag('v').WithCounter(&level)).
+		WithFlag(cli.ShortFlag('q').WithDecrementCounter(&level)).
+		Parse([]string{"command", "-qqq", "-v"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if level != 1 {
+		t.Error("expected level to be 1 but was", level)
+	}
+}
+
+func TestCommandInterpreterMultiArgument01(t *testing.T) {
+	var oldName, newName string
+	var force bool
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("rename").
+			WithArguments(
+				cli.Argument().WithName("old").WithBinding(&oldName),
+				cli.Argument().WithName("new").WithBinding(&newName))).
+		WithFlag(cli.ShortFlag('f').WithBinding(&force, false)).
+		Parse([]string{"command", "--rename", "foo", "bar", "-f"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if oldName != "foo" {
+		t.Error("expected oldName to be foo but was", oldName)
+	}
+
+	if newName != "bar" {
+		t.Error("expected newName to be bar but was", newName)
+	}
+
+	if !force {
+		t.Error("expected force to be true but was false")
+	}
+}
+
+// Pair and attached short forms
+func TestCommandInterpreterMultiArgument02(t *testing.T) {
+	var point [3]int
+	var scale [2]int
+
+	com, err := cli.Command().
+		WithFlag(cli.Flag().
+			WithShortForm('p').
+			WithLongForm("point").
+			WithArguments(
+				cli.Argument().WithBinding(&point[0]),
+				cli.Argument().WithBinding(&point[1]),
+				cli.Argument().WithBinding(&point[2]))).
+		WithFlag(cli.ShortFlag('s').
+			WithArguments(
+				cli.Argument().WithBinding(&scale[0]),
+				cli.Argument().WithBinding(&scale[1]))).
+		WithArgument(cli.Argument().WithName("file")).
+		Parse([]string{"command", "--point=1", "2", "3", "-s4", "5", "file.txt"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if point != [3]int{1, 2, 3} {
+		t.Error("expected point to be [1 2 3] but was", point)
+	}
+
+	if scale != [2]int{4, 5} {
+		t.Error("expected scale to be [4 5] but was", scale)
+	}
+
+	if arg := com.Arguments()[0]; arg.RawValue() != "file.txt" {
+		t.Error("expected file argument to be file.txt but was", arg.RawValue())
+	}
+}
+
+// Not enough values
+func TestCommandInterpreterMultiArgument03(t *testing.T) {
+	var oldName, newName string
+	var force bool
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("rename").
+			WithArguments(
+				cli.Argument().WithBinding(&oldName),
+				cli.Argument().WithBinding(&newName))).
+		WithFlag(cli.ShortFlag('f').WithBinding(&force, false)).
+		Parse([]string{"command", "--rename", "foo", "-f"})
+
+	var arityErr argo.FlagArityError
+	if !errors.As(err, &arityErr) {
+		t.Fatal("expected err to be a FlagArityError but was", err)
+	}
+
+	if arityErr.Received() != 1 {
+		t.Error("expected flag to have received 1 value but was", arityErr.Received())
+	}
+}
+
+// Defaults for every argument are applied when the flag is absent
+func TestCommandInterpreterMultiArgument04(t *testing.T) {
+	var x, y int
+
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("point").
+			WithArguments(
+				cli.Argument().WithBinding(&x).WithDefault(1),
+				cli.Argument().WithBinding(&y).WithDefault(2))).
+		Parse([]string{"command"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if x != 1 || y != 2 {
+		t.Errorf("expected point to be 1,2 but was %d,%d", x, y)
+	}
+}
+
+func TestCommandInterpreterInterspersed01(t *testing.T) {
+	var verbose bool
+
+	com, err := cli.Command().
+		WithInterspersedDisabled().
+		WithFlag(cli.ShortFlag('v').WithBinding(&verbose, false)).
+		WithArgument(cli.Argument().WithName("command")).
+		Parse([]string{"command", "-v", "ls", "-la", "-v", "--", "x"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !verbose {
+		t.Error("expected verbose to be true but was false")
+	}
+
+	if arg := com.Arguments()[0]; arg.RawValue() != "ls" {
+		t.Error("expected command argument to be ls but was", arg.RawValue())
+	}
+
+	expected := []string{"-la", "-v", "--", "x"}
+	if fmt.Sprint(com.UnmappedInputs()) != fmt.Sprint(expected) {
+		t.Error("expected unmapped inputs to be", expected, "but was", com.UnmappedInputs())
+	}
+
+	if len(com.Warnings()) > 0 {
+		t.Error("expected no warnings but got", com.Warnings())
+	}
+}
+
+// Enabled by POSIXLY_CORRECT
+func TestCommandInterpreterInterspersed02(t *testing.T) {
+	var verbose bool
+
+	t.Setenv("POSIXLY_CORRECT", "")
+
+	_, err := cli.Command().
+		WithFlag(cli.ShortFlag('v').WithBinding(&verbose, false)).
+		Parse([]string{"command", "ls", "-v"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if verbose {
+		t.Error("expected verbose to be false but was true")
+	}
+}
+
+func TestCommandInterpreterNegativeNumber01(t *testing.T) {
+	var offset int
+	var scale float64
+
+	com, err := cli.Command().
+		WithFlag(cli.Flag().
+			WithShortForm('o').
+			WithLongForm("offset").
+			WithBinding(&offset, false)).
+		WithArgument(cli.Argument().WithName("scale").WithBinding(&scale)).
+		Parse([]string{"command", "--offset", "-5", "-3.2"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if offset != -5 {
+		t.Error("expected offset to be -5 but was", offset)
+	}
+
+	if scale != -3.2 {
+		t.Error("expected scale to be -3.2 but was", scale)
+	}
+
+	if len(com.Warnings()) > 0 {
+		t.Error("expected no warnings but got", com.Warnings())
+	}
+}
+
+// Numeric binding takes priority over a matching short flag.
+func TestCommandInterpreterNegativeNumber02(t *testing.T) {
+	var offset int
+	var five bool
+
+	_, err := cli.Command().
+		WithFlag(cli.ShortFlag('o').WithBinding(&offset, false)).
+		WithFlag(cli.ShortFlag('5').WithBinding(&five, false)).
+		Parse([]string{"command", "-o", "-5"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if offset != -5 {
+		t.Error("expected offset to be -5 but was", offset)
+	}
+
+	if five {
+		t.Error("expected five to be false but was true")
+	}
+}
+
+// Non-numeric positionals only take negative numbers when no short flag
+// matches.
+func TestCommandInterpreterNegativeNumber03(t *testing.T) {
+	var five bool
+
+	com, err := cli.Command().
+		WithFlag(cli.ShortFlag('5').WithBinding(&five, false)).
+		WithArgument(cli.Argument().WithName("a")).
+		WithArgument(cli.Argument().WithName("b")).
+		Parse([]string{"command", "-7", "-5"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if arg := com.Arguments()[0]; arg.RawValue() != "-7" {
+		t.Error("expected argument a to be -7 but was", arg.RawValue())
+	}
+
+	if com.Arguments()[1].WasHit() {
+		t.Error("expected argument b to not have been hit")
+	}
+
+	if !five {
+		t.Error("expected five to be true but was false")
+	}
+}
+
+// A lone dash is a plain-text argument.
+func TestCommandInterpreterNegativeNumber04(t *testing.T) {
+	var file string
+	var verbose bool
+
+	_, err := cli.Command().
+		WithFlag(cli.ShortFlag('v').WithBinding(&verbose, false)).
+		WithArgument(cli.Argument().WithName("file").WithBinding(&file)).
+		Parse([]string{"command", "-", "-v"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if file != "-" {
+		t.Error("expected file to be - but was", file)
+	}
+
+	if !verbose {
+		t.Error("expected verbose to be true but was false")
+	}
+}
+
+func TestCommandInterpreterSuggestion01(t *testing.T) {
+	var verbose bool
+
+	ctx := new(argo.WarningContext)
+	com, err := cli.Command().
+		WithFlag(cli.LongFlag("verbose").WithBinding(&verbose, false)).
+		WithFlag(cli.LongFlag("version")).
+		Build(ctx)
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if err = argo.ParseCommand(com, []string{"command", "--verbos"}); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(com.Warnings()) != 1 || com.Warnings()[0] != "unrecognized long flag --verbos; did you mean --verbose?" {
+		t.Error("expected a single warning suggesting --verbose but got", com.Warnings())
+	}
+
+	var unknown argo.UnknownFlagError
+	if len(ctx.GetWarningErrors()) != 1 || !errors.As(ctx.GetWarningErrors()[0], &unknown) {
+		t.Fatal("expected a single UnknownFlagError warning but got", ctx.GetWarningErrors())
+	}
+
+	if unknown.Flag() != "--verbos" {
+		t.Error("expected unknown flag to be --verbos but was", unknown.Flag())
+	}
+
+	if fmt.Sprint(unknown.Suggestions()) != "[--verbose]" {
+		t.Error("expected suggestions to be [--verbose] but was", unknown.Suggestions())
+	}
+}
+
+// Suggestions disabled
+func TestCommandInterpreterSuggestion02(t *testing.T) {
+	com, err := cli.Command().
+		WithSuggestionThreshold(0).
+		WithFlag(cli.LongFlag("verbose")).
+		Parse([]string{"command", "--verbos"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(com.Warnings()) != 1 || com.Warnings()[0] != "unrecognized long flag --verbos" {
+		t.Error("expected a single warning with no suggestions but got", com.Warnings())
+	}
+}
+
+// Invalid flag value
+func TestCommandInterpreterParseError01(t *testing.T) {
+	var count int
+
+	_, err := cli.Command().
+		WithRuntime(&argo.Runtime{ProgramName: "command"}).
+		WithFlag(cli.LongFlag("count").WithBinding(&count, true)).
+		Parse([]string{"command", "--count", "abc"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorInvalidValue {
+		t.Error("expected error code to be invalid-value but was", parseErr.Code())
+	}
+
+	if fmt.Sprint(parseErr.CommandPath()) != "[command]" {
+		t.Error("expected command path to be [command] but was", parseErr.CommandPath())
+	}
+
+	if parseErr.Position() != 2 || parseErr.Token() != "abc" {
+		t.Errorf("expected error to be at position 2 (abc) but was %d (%s)", parseErr.Position(), parseErr.Token())
+	}
+}
+
+// Missing flag value
+func TestCommandInterpreterParseError02(t *testing.T) {
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("name").WithArgument(cli.Argument().Require())).
+		Parse([]string{"command", "-v", "--name"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorMissingFlagValue {
+		t.Error("expected error code to be missing-flag-value but was", parseErr.Code())
+	}
+
+	if parseErr.Position() != 2 || parseErr.Token() != "--name" {
+		t.Errorf("expected error to be at position 2 (--name) but was %d (%s)", parseErr.Position(), parseErr.Token())
+	}
+
+	var missing argo.MissingRequiredArgumentError
+	if !errors.As(err, &missing) || !missing.HasFlag() {
+		t.Error("expected err to wrap a MissingRequiredArgumentError but was", err)
+	}
+}
+
+// Post-parse errors collected into a MultiError
+func TestCommandInterpreterParseError03(t *testing.T) {
+	_, err := cli.Command().
+		WithFlag(cli.LongFlag("name").Require()).
+		WithArgument(cli.Argument().WithName("file").Require()).
+		Parse([]string{"command"})
+
+	var multi argo.MultiError
+	if !errors.As(err, &multi) {
+		t.Fatal("expected err to be a MultiError but was", err)
+	}
+
+	if len(multi.Errors()) != 2 {
+		t.Fatal("expected 2 errors but got", multi.Errors())
+	}
+
+	codes := make([]argo.ParseErrorCode, 0, 2)
+	for _, err := range multi.Errors() {
+		var parseErr argo.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatal("expected err to be a ParseError but was", err)
+		}
+
+		if parseErr.Position() != -1 || parseErr.Token() != "" {
+			t.Errorf("expected error to have no position but was %d (%s)", parseErr.Position(), parseErr.Token())
+		}
+
+		codes = append(codes, parseErr.Code())
+	}
+
+	if fmt.Sprint(codes) != "[constraint-violation missing-argument]" {
+		t.Error("expected error codes to be [constraint-violation missing-argument] but was", codes)
+	}
+
+	var missing argo.MissingFlagError
+	if !errors.As(err, &missing) || missing.Flag().LongForm() != "name" {
+		t.Error("expected err to contain a MissingFlagError for --name but was", err)
+	}
+}
+
+// Strict mode unknown long flag
+func TestCommandInterpreterStrict01(t *testing.T) {
+	_, err := cli.Command().
+		WithStrictParsing().
+		WithFlag(cli.LongFlag("verbose")).
+		Parse([]string{"command", "--verbos"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorUnknownFlag || parseErr.Position() != 1 {
+		t.Errorf("expected unknown-flag error at position 1 but was %s at %d", parseErr.Code(), parseErr.Position())
+	}
+
+	var unknown argo.UnknownFlagError
+	if !errors.As(err, &unknown) || fmt.Sprint(unknown.Suggestions()) != "[--verbose]" {
+		t.Error("expected err to be an UnknownFlagError suggesting --verbose but was", err)
+	}
+}
+
+// Strict mode stray positional argument
+func TestCommandInterpreterStrict02(t *testing.T) {
+	_, err := cli.Command().
+		WithStrictParsing().
+		WithArgument(cli.Argument().WithName("file")).
+		Parse([]string{"command", "a", "b"})
+
+	var parseErr argo.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatal("expected err to be a ParseError but was", err)
+	}
+
+	if parseErr.Code() != argo.ParseErrorTooManyArguments {
+		t.Error("expected error code to be too-many-arguments but was", parseErr.Code())
+	}
+
+	if parseErr.Position() != 2 || parseErr.Token() != "b" {
+		t.Errorf("expected error to be at position 2 (b) but was %d (%s)", parseErr.Position(), parseErr.Token())
+	}
+
+	var tooMany argo.TooManyArgumentsError
+	if !errors.As(err, &tooMany) || tooMany.Value() != "b" {
+		t.Error("expected err to be a TooManyArgumentsError for b but was", err)
+	}
+}
+
+// Strict mode with an unmapped label
+func TestCommandInterpreterStrict03(t *testing.T) {
+	com, err := cli.Command().
+		WithStrictParsing().
+		WithUnmappedLabel("FILES...").
+		Parse([]string{"command", "a", "b"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if fmt.Sprint(com.UnmappedInputs()) != "[a b]" {
+		t.Error("expected unmapped inputs to be [a b] but was", com.UnmappedInputs())
+	}
+}
+
+// Strict mode unknown short flags with attached values
+func TestCommandInterpreterStrict04(t *testing.T) {
+	for _, arg := range []string{"-x=foo", "-=foo"} {
+		_, err := cli.Command().
+			WithStrictParsing().
+			Parse([]string{"command", arg})
+
+		var parseErr argo.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatal("expected err to be a ParseError for", arg, "but was", err)
+		}
+
+		if parseErr.Code() != argo.ParseErrorUnknownFlag || parseErr.Token() != arg {
+			t.Errorf("expected unknown-flag error for %s but was %s for %s", arg, parseErr.Code(), parseErr.Token())
+		}
+	}
+}
+
+// Deprecated flag replaced by another flag
+func TestCommandInterpreterDeprecated01(t *testing.T) {
+	var color, colour bool
+
+	com, err := cli.Command().
+		WithFlag(cli.LongFlag("color").WithBinding(&color, false).Negatable()).
+		WithFlag(cli.LongFlag("colour").WithBinding(&colour, false).Negatable().
+			Hidden().
+			Deprecated("").
+			ReplacedBy("color")).
+		Parse([]string{"command", "--colour"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !com.FindLongFlag("color").WasHit() || com.FindLongFlag("colour").WasHit() {
+		t.Error("expected --colour to be replaced by --color")
+	}
+
+	if fmt.Sprint(com.Warnings()) != "[flag --colour is deprecated; use --color instead]" {
+		t.Error("expected a deprecation warning but was", com.Warnings())
+	}
+
+	com, err = cli.Command().
+		WithFlag(cli.LongFlag("color").WithBinding(&color, false).Negatable()).
+		WithFlag(cli.LongFlag("colour").WithBinding(&colour, false).Negatable().
+			Deprecated("").
+			ReplacedBy("--color")).
+		Parse([]string{"command", "--no-colour"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if flag := com.FindLongFlag("color"); !flag.WasHit() || !flag.WasNegated() {
+		t.Error("expected --no-colour to be replaced by --no-color")
+	}
+}
+
+// Deprecated flag without a replacement
+func TestCommandInterpreterDeprecated02(t *testing.T) {
+	com, err := cli.Command().
+		WithFlag(cli.ShortFlag('q').Deprecated("output is quiet by default")).
+		Parse([]string{"command", "-q"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !com.FindShortFlag('q').WasHit() {
+		t.Error("expected -q to have been hit")
+	}
+
+	if fmt.Sprint(com.Warnings()) != "[flag -q is deprecated: output is quiet by default]" {
+		t.Error("expected a deprecation warning but was", com.Warnings())
+	}
+}
+
+// Deprecated positional argument
+func TestCommandInterpreterDeprecated03(t *testing.T) {
+	var files []string
+
+	com, err := cli.Command().
+		WithArgument(cli.Argument().WithName("mode").Deprecated("use --mode instead")).
+		WithArgument(cli.Argument().WithBinding(&files).Deprecated("")).
+		Parse([]string{"command", "fast", "a", "b"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(files) != 2 {
+		t.Error("expected 2 files but got", files)
+	}
+
+	expected := `[argument "mode" is deprecated: use --mode instead argument 2 is deprecated]`
+	if fmt.Sprint(com.Warnings()) != expected {
+		t.Error("expected deprecation warnings but was", com.Warnings())
+	}
+}
@@ -0,0 +1,141 @@
+package argo
+
+import (
+	"io"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+	"github.com/Foxcapades/Argonaut/internal/util"
+)
+
+// CompletionCommandName is the name of the hidden subcommand used to invoke a
+// command tree in dynamic completion mode.
+//
+// When a command tree is called with this value as its first argument, the
+// remaining arguments are treated as a partial command line, the last of which
+// is the token currently being completed.  Rather than executing the command,
+// the candidates for that token are printed to stdout, one per line.
+//
+// Example:
+//     $ my-app __complete files remove --output ""
+const CompletionCommandName = "__complete"
+
+func isCompletionCall(args []string) bool {
+	return len(args) > 1 && args[1] == CompletionCommandName
+}
+
+// CommandTreeCompletions returns the completion candidates for the last of the
+// given arguments when interpreted against the given CommandTree.
+//
+// The first argument is expected to be the program name, as with os.Args, and
+// the last argument is the partial token currently being completed, which may
+// be empty.  The arguments between them are interpreted in a tolerant mode that
+// does not fail on missing or invalid values and does not execute any
+// callbacks.
+//
+// The given tree should be freshly built and not previously parsed.
+//
+// Example:
+//     tree, _ := cli.Tree().
+//         WithLeaf(cli.Leaf("build")).
+//         Build(new(argo.WarningContext))
+//
+//     argo.CommandTreeCompletions(tree, []string{"my-app", "bu"}) // [build]
+func CommandTreeCompletions(tree CommandTree, args []string) []string {
+	var prefix string
+
+	words := args
+	if len(args) > 1 {
+		words = args[:len(args)-1]
+		prefix = args[len(args)-1]
+	}
+
+	interpreter := newCommandTreeInterpreter(words, tree).(*commandTreeInterpreter)
+	interpreter.completion = true
+
+	return interpreter.complete(prefix)
+}
+
+// runCompletion prints the completion candidates for the given `__complete`
+// call arguments to stdout and exits.
+func runCompletion(args []string, tree CommandTree) {
+	words := append([]string{args[0]}, args[2:]...)
+	rt := tree.getRuntime()
+	util.Must(writeCompletions(CommandTreeCompletions(tree, words), rt.stdout()))
+	rt.exit(0)
+}
+
+// complete runs the interpreter over the partial command line and returns the
+// completion candidates for the given prefix.
+func (c *commandTreeInterpreter) complete(prefix string) []string {
+	if err := c.Run(); err != nil || c.boundary {
+		return nil
+	}
+
+	if c.awaiting != nil {
+		return c.awaiting.Argument().Completions(prefix)
+	}
+
+	if strings.HasPrefix(prefix, chars.StrDoubleDash) && !c.positional {
+		if idx := strings.IndexByte(prefix, chars.CharEquals); idx > -1 {
+			if f := c.current.FindLongFlag(prefix[2:idx]); f != nil && f.HasArgument() {
+				values := f.Argument().Completions(prefix[idx+1:])
+				out := make([]string, len(values))
+
+				for i := range values {
+					out[i] = prefix[:idx+1] + values[i]
+				}
+
+				return out
+			}
+
+			return nil
+		}
+	}
+
+	node := newCommandNodeCompletionNode(c.current, nil)
+
+	if strings.HasPrefix(prefix, chars.StrDash) && !c.positional {
+		return filterCompletions(node.flagWords(), prefix)
+	}
+
+	if _, ok := c.current.(CommandParent); ok {
+		return filterCompletions(node.childWords(), prefix)
+	}
+
+	if leaf, ok := c.current.(CommandLeaf); ok {
+		for _, arg := range leaf.Arguments() {
+			if arg.acceptsValue() {
+				if arg.IsHidden() {
+					return nil
+				}
+
+				return arg.Completions(prefix)
+			}
+		}
+	}
+
+	return nil
+}
+
+func filterCompletions(words []string, prefix string) []string {
+	out := make([]string, 0, len(words))
+
+	for _, word := range words {
+		if strings.HasPrefix(word, prefix) {
+			out = append(out, word)
+		}
+	}
+
+	return out
+}
+
+func writeCompletions(candidates []string, writer io.Writer) error {
+	for _, candidate := range candidates {
+		if _, err := io.WriteString(writer, candidate+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
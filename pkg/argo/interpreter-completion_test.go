@@ -0,0 +1,130 @@
+package argo_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+func completionTestBuilder(hit *bool) argo.CommandTreeBuilder {
+	refs := func(prefix string) []string {
+		out := make([]string, 0, 2)
+		for _, ref := range []string{"main", "master", "develop"} {
+			if strings.HasPrefix(ref, prefix) {
+				out = append(out, ref)
+			}
+		}
+		return out
+	}
+
+	return argo.NewCommandTreeBuilder().
+		WithCallback(func(argo.CommandTree) { *hit = true }).
+		WithFlag(argo.NewFlagBuilder().WithShortForm('v').WithLongForm("verbose")).
+		WithLeaf(argo.NewCommandLeafBuilder("checkout").
+			WithCallback(func(argo.CommandLeaf) { *hit = true }).
+			WithFlag(argo.NewFlagBuilder().
+				WithShortForm('b').
+				WithLongForm("branch").
+				WithArgument(argo.NewArgumentBuilder().Require().WithCompleter(refs))).
+			WithArgument(argo.NewArgumentBuilder().Require().WithCompleter(refs)).
+			WithArgument(argo.NewArgumentBuilder().Require().WithCompleter(func(string) []string {
+				return []string{"second"}
+			}))).
+		WithLeaf(argo.NewCommandLeafBuilder("commit").WithAliases("ci"))
+}
+
+func completionTestRun(t *testing.T, args ...string) []string {
+	var hit bool
+
+	tree, err := completionTestBuilder(&hit).Build(new(argo.WarningContext))
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	out := argo.CommandTreeCompletions(tree, append([]string{"command"}, args...))
+
+	if hit {
+		t.Error("expected callbacks not to be executed in completion mode")
+	}
+
+	return out
+}
+
+// Subcommand names and aliases are completed.
+func TestCommandTreeCompletions01(t *testing.T) {
+	out := completionTestRun(t, "c")
+
+	if !slices.Equal(out, []string{"checkout", "commit", "ci"}) {
+		t.Error("expected [checkout commit ci] but was", out)
+	}
+}
+
+// Flags are completed, including inherited flags.
+func TestCommandTreeCompletions02(t *testing.T) {
+	out := completionTestRun(t, "checkout", "--")
+
+	if !slices.Equal(out, []string{"--branch", "--help", "--verbose"}) {
+		t.Error("expected [--branch --help --verbose] but was", out)
+	}
+}
+
+// Flag arguments use the argument's completer.
+func TestCommandTreeCompletions03(t *testing.T) {
+	out := completionTestRun(t, "checkout", "-b", "ma")
+
+	if !slices.Equal(out, []string{"main", "master"}) {
+		t.Error("expected [main master] but was", out)
+	}
+
+	out = completionTestRun(t, "checkout", "--branch=de")
+
+	if !slices.Equal(out, []string{"--branch=develop"}) {
+		t.Error("expected [--branch=develop] but was", out)
+	}
+}
+
+// Positional arguments are completed in order, and missing required arguments
+// do not cause a failure.
+func TestCommandTreeCompletions04(t *testing.T) {
+	out := completionTestRun(t, "checkout", "d")
+
+	if !slices.Equal(out, []string{"develop"}) {
+		t.Error("expected [develop] but was", out)
+	}
+
+	out = completionTestRun(t, "checkout", "main", "")
+
+	if !slices.Equal(out, []string{"second"}) {
+		t.Error("expected [second] but was", out)
+	}
+}
+
+// Unknown subcommands produce no candidates.
+func TestCommandTreeCompletions05(t *testing.T) {
+	if out := completionTestRun(t, "nope", ""); len(out) != 0 {
+		t.Error("expected no candidates but got", out)
+	}
+}
+
+// Hidden subcommands and flags are not completed.
+func TestCommandTreeCompletions06(t *testing.T) {
+	tree, err := argo.NewCommandTreeBuilder().
+		WithLeaf(argo.NewCommandLeafBuilder("status")).
+		WithLeaf(argo.NewCommandLeafBuilder("stash").Hidden()).
+		WithFlag(argo.NewFlagBuilder().WithLongForm("debug").Hidden()).
+		WithFlag(argo.NewFlagBuilder().WithLongForm("dry-run")).
+		Build(new(argo.WarningContext))
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if out := argo.CommandTreeCompletions(tree, []string{"command", "st"}); !slices.Equal(out, []string{"status"}) {
+		t.Error("expected [status] but was", out)
+	}
+
+	if out := argo.CommandTreeCompletions(tree, []string{"command", "--d"}); !slices.Equal(out, []string{"--dry-run"}) {
+		t.Error("expected [--dry-run] but was", out)
+	}
+}
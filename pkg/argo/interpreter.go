@@ -1,13 +1,108 @@
 package argo
 
 import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
 	"reflect"
+	"strconv"
+	"syscall"
 
 	"github.com/Foxcapades/Argonaut/internal/emit"
 	"github.com/Foxcapades/Argonaut/internal/parse"
 	"github.com/Foxcapades/Argonaut/internal/util"
 )
 
+// ParseCommand parses the given CLI arguments into the given, already built,
+// Command instance.
+//
+// This is the parsing step of CommandBuilder.Parse, and allows callers to
+// retain the built Command, and the WarningContext used to build it, even when
+// parsing fails.
+func ParseCommand(command Command, args []string) error {
+	if command.expandsResponseFiles() {
+		var err error
+		if args, err = expandResponseFiles(args, command.getRuntime()); err != nil {
+			return err
+		}
+	}
+
+	return newCommandInterpreter(args, command).Run()
+}
+
+// ParseCommandTree parses the given CLI arguments into the given, already
+// built, CommandTree instance.
+//
+// This is the parsing step of CommandTreeBuilder.Parse, including handling of
+// dynamic completion calls, and allows callers to retain the built
+// CommandTree, and the WarningContext used to build it, even when parsing
+// fails.  If a leaf command was reached before parsing failed, it will be
+// available from the tree's SelectedCommand method.
+func ParseCommandTree(tree CommandTree, args []string) error {
+	if isCompletionCall(args) {
+		runCompletion(args, tree)
+		return newExitError(0)
+	}
+
+	if tree.expandsResponseFiles() {
+		var err error
+		if args, err = expandResponseFiles(args, tree.getRuntime()); err != nil {
+			return err
+		}
+	}
+
+	return newCommandTreeInterpreter(args, tree).Run()
+}
+
+// ExecuteCommand parses the given CLI arguments into the given, already built,
+// Command instance, then calls the Command's handler, if it has one.
+//
+// This is the parsing and execution step of CommandBuilder.Execute.
+func ExecuteCommand(ctx context.Context, command Command, args []string) error {
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	if err := ParseCommand(command, args); err != nil {
+		return usageError(err)
+	}
+
+	return command.executeHandler(ctx)
+}
+
+// ExecuteCommandTree parses the given CLI arguments into the given, already
+// built, CommandTree instance, then calls the handler of the selected
+// CommandLeaf, if it has one.
+//
+// This is the parsing and execution step of CommandTreeBuilder.Execute.
+func ExecuteCommandTree(ctx context.Context, tree CommandTree, args []string) error {
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	if err := ParseCommandTree(tree, args); err != nil {
+		return usageError(err)
+	}
+
+	return tree.SelectedCommand().executeHandler(ctx)
+}
+
+// signalContext returns a copy of the given context that will be cancelled
+// when the process receives SIGINT or SIGTERM.
+func signalContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+}
+
+// usageError wraps the given parse error in an ExitError with the code
+// ExitCodeUsage, unless the error is already an ExitError.
+func usageError(err error) error {
+	var exit ExitError
+	if errors.As(err, &exit) {
+		return err
+	}
+
+	return WithExitCode(err, ExitCodeUsage)
+}
+
 func newCommandInterpreter(args []string, command Command) interpreter {
 	return &commandInterpreter{
 		parser:   parse.NewParser(emit.NewEmitter(args)),
@@ -34,3 +129,122 @@ type interpreter interface {
 func hasBooleanArgument(flag Flag) bool {
 	return flag.HasArgument() && flag.Argument().HasBinding() && flag.Argument().BindingType().Kind() == reflect.Bool
 }
+
+// setFlagDefaults sets every argument of the given flag that has a default
+// value to that default, appending any errors to the given MultiError.
+func setFlagDefaults(flag Flag, errs MultiError) {
+	for _, arg := range flag.Arguments() {
+		if arg.HasDefault() {
+			if err := arg.setToDefault(); err != nil {
+				errs.AppendError(err)
+			}
+		}
+	}
+}
+
+// isNegativeNumber tests whether the given CLI input is a negative number, for
+// example "-5", "-3.2", or "-0x1F".
+func isNegativeNumber(value string) bool {
+	if len(value) < 2 || value[0] != '-' {
+		return false
+	}
+
+	// Require a digit up front so that flag blocks like "-inf" or "-nan" are not
+	// mistaken for numbers.
+	if !isDigit(value[1]) && !(value[1] == '.' && len(value) > 2 && isDigit(value[2])) {
+		return false
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return true
+	}
+
+	_, err := strconv.ParseInt(value, 0, 64)
+	return err == nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// hasNumericBinding tests whether the given argument is bound to a numeric
+// type, or to a slice of a numeric type.
+func hasNumericBinding(arg Argument) bool {
+	if arg == nil || !arg.HasBinding() {
+		return false
+	}
+
+	kind := arg.BindingType().Kind()
+	typ := arg.BindingType()
+
+	for kind == reflect.Pointer || kind == reflect.Slice || kind == reflect.Array {
+		typ = typ.Elem()
+		kind = typ.Kind()
+	}
+
+	return (kind >= reflect.Int && kind <= reflect.Uint64) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// isNegativeNumberValue tests whether the given element is a negative number
+// that should be treated as a value for the given argument rather than as a
+// block of short flags.
+//
+// A negative number is treated as a value if the argument it would be passed
+// to is bound to a numeric type, or if no short flag matches its first digit.
+func isNegativeNumberValue(element *parse.Element, arg Argument, finder flagFinder) bool {
+	if element.Type != parse.ElementTypeShortBlockSolo || !isNegativeNumber(element.String()) {
+		return false
+	}
+
+	return hasNumericBinding(arg) || finder.FindShortFlag(element.Data[0][0]) == nil
+}
+
+// nextPositionalArgument returns the first of the given positional arguments
+// that may still be assigned a value, or nil if there is no such argument.
+func nextPositionalArgument(args []Argument) Argument {
+	for _, arg := range args {
+		if arg.acceptsValue() {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// toParseError wraps the given error in a ParseError carrying the given command
+// path, if the error was caused by the CLI inputs.
+//
+// The given element is the CLI input being interpreted when the error was
+// encountered, and last is the most recently consumed CLI input, which may be
+// a value following the element.  Invalid value errors are attributed to the
+// last input, all other errors are attributed to the element.  If element is
+// nil, the error is not attributed to any specific input.
+func toParseError(err error, path []string, element, last *parse.Element) error {
+	code, ok := parseErrorCode(err)
+	if !ok {
+		return err
+	}
+
+	if element == nil {
+		return newParseError(code, path, -1, "", err)
+	}
+
+	if code == ParseErrorInvalidValue && last != nil && last.Type != parse.ElementTypeEnd {
+		element = last
+	}
+
+	return newParseError(code, path, element.Position, element.String(), err)
+}
+
+// toParseErrors wraps each of the errors in the given MultiError in a
+// ParseError carrying the given command path, if the error was caused by the
+// CLI inputs.
+func toParseErrors(errs MultiError, path []string) MultiError {
+	out := newMultiError()
+
+	for _, err := range errs.Errors() {
+		out.AppendError(toParseError(err, path, nil, nil))
+	}
+
+	return out
+}
@@ -0,0 +1,294 @@
+package argo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	cli "github.com/Foxcapades/Argonaut"
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+// Execute calls the selected leaf's handler and returns its error
+func TestCommandTreeBuilder_Execute_handlerError(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+	expected := errors.New("deploy failed")
+	var selected string
+
+	err := cli.Tree().
+		WithRuntime(rt).
+		WithLeaf(cli.Leaf("deploy").
+			WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				selected = leaf.Name()
+				return expected
+			})).
+		WithLeaf(cli.Leaf("status").
+			WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				t.Error("expected status handler not to be called")
+				return nil
+			})).
+		Execute(context.Background(), []string{"my-app", "deploy"})
+
+	if err != expected {
+		t.Error("expected err to be the handler error but was", err)
+	}
+
+	if selected != "deploy" {
+		t.Error("expected handler to receive the deploy leaf but was", selected)
+	}
+
+	if argo.ExitCode(err) != argo.ExitCodeFailure {
+		t.Error("expected exit code to be 1 but was", argo.ExitCode(err))
+	}
+}
+
+// Handlers may request a specific exit code
+func TestCommandTreeBuilder_Execute_withExitCode(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+	cause := errors.New("partial failure")
+
+	err := cli.Tree().
+		WithRuntime(rt).
+		WithLeaf(cli.Leaf("sync").
+			WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				return argo.WithExitCode(cause, 3)
+			})).
+		Execute(context.Background(), []string{"my-app", "sync"})
+
+	if argo.ExitCode(err) != 3 {
+		t.Error("expected exit code to be 3 but was", argo.ExitCode(err))
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("expected err to wrap the handler error but was", err)
+	}
+}
+
+// Parse errors are returned with the usage exit code and handlers are not
+// called
+func TestCommandTreeBuilder_Execute_parseError(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+	var value int
+
+	err := cli.Tree().
+		WithRuntime(rt).
+		WithLeaf(cli.Leaf("leaf").
+			WithFlag(cli.LongFlag("count").WithBinding(&value, true)).
+			WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				t.Error("expected handler not to be called")
+				return nil
+			})).
+		Execute(context.Background(), []string{"my-app", "leaf", "--count=nope"})
+
+	if argo.ExitCode(err) != argo.ExitCodeUsage {
+		t.Error("expected exit code to be 2 but was", argo.ExitCode(err))
+	}
+
+	var ee argo.ExitError
+	if !errors.As(err, &ee) || ee.Unwrap() == nil {
+		t.Error("expected err to wrap the parse error but was", err)
+	}
+}
+
+// Help flags map to the exit code passed to the runtime
+func TestCommandTreeBuilder_Execute_help(t *testing.T) {
+	rt, _, _, code := newTestRuntime()
+
+	err := cli.Tree().
+		WithRuntime(rt).
+		WithLeaf(cli.Leaf("leaf").
+			WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				t.Error("expected handler not to be called")
+				return nil
+			})).
+		Execute(context.Background(), []string{"my-app", "leaf", "--help"})
+
+	var ee argo.ExitError
+	if !errors.As(err, &ee) {
+		t.Error("expected err to be an ExitError but was", err)
+	}
+
+	if argo.ExitCode(err) != 0 || *code != 0 {
+		t.Error("expected exit code to be 0 but was", argo.ExitCode(err))
+	}
+}
+
+// SIGINT cancels the handler context
+func TestCommandTreeBuilder_Execute_interrupt(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+
+	err := cli.Tree().
+		WithRuntime(rt).
+		WithLeaf(cli.Leaf("serve").
+			WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				proc, err := os.FindProcess(os.Getpid())
+				if err != nil {
+					t.Skip("cannot find the current process:", err)
+				}
+
+				if err = proc.Signal(os.Interrupt); err != nil {
+					t.Skip("cannot send interrupt on this platform:", err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(5 * time.Second):
+					return errors.New("context was not cancelled")
+				}
+			})).
+		Execute(context.Background(), []string{"my-app", "serve"})
+
+	if argo.ExitCode(err) != argo.ExitCodeInterrupted {
+		t.Error("expected exit code to be 130 but was", err)
+	}
+}
+
+// Execute calls the command handler after callbacks
+func TestCommandBuilder_Execute(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+	order := make([]string, 0, 2)
+
+	err := cli.Command().
+		WithRuntime(rt).
+		WithCallback(func(argo.Command) { order = append(order, "callback") }).
+		WithHandler(func(ctx context.Context, com argo.Command) error {
+			order = append(order, "handler")
+			return nil
+		}).
+		Execute(context.Background(), []string{"my-app"})
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	}
+
+	if len(order) != 2 || order[0] != "callback" || order[1] != "handler" {
+		t.Error("expected callback to be called before handler but was", order)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, 0},
+		{errors.New("failed"), 1},
+		{argo.WithExitCode(errors.New("failed"), 4), 4},
+		{context.Canceled, 130},
+	}
+
+	for _, test := range tests {
+		if code := argo.ExitCode(test.err); code != test.code {
+			t.Errorf("expected exit code for %v to be %d but was %d", test.err, test.code, code)
+		}
+	}
+}
+
+// Hooks and middleware wrap the leaf handler from the root down
+func TestCommandTreeBuilder_Execute_hooks(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+	failure := errors.New("leaf failed")
+	calls := make([]string, 0, 10)
+
+	middleware := func(name string) argo.CommandLeafMiddleware {
+		return func(next argo.CommandLeafHandler) argo.CommandLeafHandler {
+			return func(ctx context.Context, leaf argo.CommandLeaf) error {
+				calls = append(calls, name+":before")
+				err := next(ctx, leaf)
+				calls = append(calls, name+":after")
+				return err
+			}
+		}
+	}
+
+	err := cli.Tree().
+		WithRuntime(rt).
+		WithMiddleware(middleware("tree-mw")).
+		WithPreRun(func(ctx context.Context, leaf argo.CommandLeaf) error {
+			calls = append(calls, "tree-pre")
+			return nil
+		}).
+		WithPostRun(func(ctx context.Context, leaf argo.CommandLeaf, err error) error {
+			calls = append(calls, "tree-post")
+			return err
+		}).
+		WithBranch(cli.Branch("branch").
+			WithMiddleware(middleware("branch-mw")).
+			WithPreRun(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				calls = append(calls, "branch-pre")
+				return nil
+			}).
+			WithPostRun(func(ctx context.Context, leaf argo.CommandLeaf, err error) error {
+				if err != failure {
+					t.Error("expected post-run hook to receive the leaf failure but got", err)
+				}
+				calls = append(calls, "branch-post:"+leaf.Name())
+				return err
+			}).
+			WithLeaf(cli.Leaf("leaf").
+				WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+					calls = append(calls, "leaf")
+					return failure
+				}))).
+		Execute(context.Background(), []string{"my-app", "branch", "leaf"})
+
+	if err != failure {
+		t.Error("expected err to be the leaf failure but was", err)
+	}
+
+	expected := []string{
+		"tree-mw:before",
+		"tree-pre",
+		"branch-mw:before",
+		"branch-pre",
+		"leaf",
+		"branch-post:leaf",
+		"branch-mw:after",
+		"tree-post",
+		"tree-mw:after",
+	}
+
+	if len(calls) != len(expected) {
+		t.Fatal("expected calls to be", expected, "but was", calls)
+	}
+
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Fatal("expected calls to be", expected, "but was", calls)
+		}
+	}
+}
+
+// A failing pre-run hook skips the leaf handler but not the post-run hooks
+func TestCommandTreeBuilder_Execute_preRunFailure(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+	failure := errors.New("unauthorized")
+	postRan := false
+
+	err := cli.Tree().
+		WithRuntime(rt).
+		WithPreRun(func(ctx context.Context, leaf argo.CommandLeaf) error {
+			return failure
+		}).
+		WithPostRun(func(ctx context.Context, leaf argo.CommandLeaf, err error) error {
+			postRan = true
+			return argo.WithExitCode(err, 77)
+		}).
+		WithLeaf(cli.Leaf("leaf").
+			WithHandler(func(ctx context.Context, leaf argo.CommandLeaf) error {
+				t.Error("expected leaf handler not to be called")
+				return nil
+			})).
+		Execute(context.Background(), []string{"my-app", "leaf"})
+
+	if !postRan {
+		t.Error("expected post-run hook to be called")
+	}
+
+	if !errors.Is(err, failure) || argo.ExitCode(err) != 77 {
+		t.Error("expected err to be the pre-run failure with exit code 77 but was", err)
+	}
+}
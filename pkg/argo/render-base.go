@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/Foxcapades/Argonaut/internal/chars"
 )
@@ -13,28 +14,38 @@ const (
 	argReqSuffix = '>'
 	argOptPrefix = '['
 	argOptSuffix = ']'
+
+	envVarPrefix = "  [env: "
+
+	variadicSuffix = "..."
 )
 
 type renderBase struct{}
 
 func renderArgName(a Argument, argIndex int) string {
+	var name string
+
 	if a.HasName() {
-		return a.Name()
+		name = a.Name()
+	} else if argIndex > 0 {
+		name = "arg" + strconv.Itoa(argIndex)
+	} else {
+		name = "arg"
 	}
 
-	if argIndex > 0 {
-		return "arg" + strconv.Itoa(argIndex)
+	if a.IsVariadic() {
+		return name + variadicSuffix
 	}
 
-	return "arg"
+	return name
 }
 
-func renderFlagArgument(arg Argument, padding uint8, out *bufio.Writer) error {
+func renderFlagArgument(arg Argument, padding uint8, out *bufio.Writer, argIndex int) error {
 	if _, err := out.WriteString(chars.SubLinePadding[padding]); err != nil {
 		return err
 	}
 
-	if err := renderArgumentName(arg, out, 0); err != nil {
+	if err := renderArgumentName(arg, out, argIndex); err != nil {
 		return err
 	}
 
@@ -59,6 +70,9 @@ func renderArgument(arg Argument, padding uint8, out *bufio.Writer, argIndex int
 	if err := renderArgumentName(arg, out, argIndex); err != nil {
 		return err
 	}
+	if err := renderArgumentEnvVar(arg, out); err != nil {
+		return err
+	}
 
 	if arg.HasDescription() {
 		if err := out.WriteByte(chars.CharLF); err != nil {
@@ -106,97 +120,29 @@ func renderArgumentName(a Argument, out *bufio.Writer, argIndex int) error {
 	return nil
 }
 
-func flagArgShouldBeRendered(arg Argument) bool {
-	return arg.IsRequired() ||
-		!arg.HasBinding() ||
-		arg.BindingType().Kind() != reflect.Bool
-}
+func renderArgumentEnvVar(a Argument, out *bufio.Writer) error {
+	if !a.HasEnvVar() {
+		return nil
+	}
 
-func renderFlag(flag Flag, padding uint8, sb *bufio.Writer) error {
-	if _, err := sb.WriteString(chars.HeaderPadding[padding]); err != nil {
+	if _, err := out.WriteString(envVarPrefix); err != nil {
 		return err
 	}
-
-	// If the flag has a long form name
-	if flag.HasLongForm() {
-
-		// AND a short form character
-		if flag.HasShortForm() {
-			if err := sb.WriteByte(chars.CharDash); err != nil {
-				return err
-			}
-			if err := sb.WriteByte(flag.ShortForm()); err != nil {
-				return err
-			}
-
-			if flag.HasArgument() && flagArgShouldBeRendered(flag.Argument()) {
-				if err := sb.WriteByte(chars.CharSpace); err != nil {
-					return err
-				}
-				if err := renderArgumentName(flag.Argument(), sb, 0); err != nil {
-					return err
-				}
-			}
-
-			if _, err := sb.WriteString(chars.FlagDivider); err != nil {
-				return err
-			}
-		}
-
-		if _, err := sb.WriteString(chars.StrDoubleDash); err != nil {
-			return err
-		}
-		if _, err := sb.WriteString(flag.LongForm()); err != nil {
-			return err
-		}
-
-		if flag.HasArgument() && flagArgShouldBeRendered(flag.Argument()) {
-			if err := sb.WriteByte(chars.CharEquals); err != nil {
-				return err
-			}
-			if err := renderArgumentName(flag.Argument(), sb, 0); err != nil {
-				return err
-			}
-		}
-	} else {
-		if err := sb.WriteByte(chars.CharDash); err != nil {
-			return err
-		}
-		if err := sb.WriteByte(flag.ShortForm()); err != nil {
-			return err
-		}
-
-		if flag.HasArgument() && flagArgShouldBeRendered(flag.Argument()) {
-			if err := sb.WriteByte(chars.CharSpace); err != nil {
-				return err
-			}
-			if err := renderArgumentName(flag.Argument(), sb, 0); err != nil {
-				return err
-			}
-		}
+	if _, err := out.WriteString(a.EnvVar()); err != nil {
+		return err
 	}
 
-	if flag.HasDescription() {
-		if err := sb.WriteByte(chars.CharLF); err != nil {
-			return err
-		}
-
-		formatter := chars.NewDescriptionFormatter(chars.DescriptionPadding[padding], chars.HelpTextMaxWidth, sb)
-		if err := formatter.Format(flag.Description()); err != nil {
-			return err
-		}
-	}
+	return out.WriteByte(argOptSuffix)
+}
 
-	if flag.HasArgument() && flag.Argument().HasDescription() {
-		if err := sb.WriteByte(chars.CharLF); err != nil {
-			return err
-		}
-		if err := renderFlagArgument(flag.Argument(), padding+1, sb); err != nil {
-			return err
-		}
-	}
+func flagArgShouldBeRendered(arg Argument) bool {
+	return arg.IsRequired() ||
+		!arg.HasBinding() ||
+		arg.BindingType().Kind() != reflect.Bool
+}
 
-	return nil
+func renderFlag(flag Flag, padding uint8, sb *bufio.Writer) error {
+	return renderFlagForms(flag, flag.HasShortForm(), flag.HasLongForm(), padding, sb)
 }
 
 func (r renderBase) renderShortestFlagLine(flag Flag, sb *bufio.Writer) error {
@@ -212,15 +158,12 @@ func (r renderBase) renderShortestFlagLine(flag Flag, sb *bufio.Writer) error {
 			if err := sb.WriteByte(chars.CharEquals); err != nil {
 				return err
 			}
-			if err := renderArgumentName(flag.Argument(), sb, 0); err != nil {
+			if err := renderFlagArgumentNames(flag, sb); err != nil {
 				return err
 			}
 		}
 	} else {
-		if _, err := sb.WriteString(chars.StrDoubleDash); err != nil {
-			return err
-		}
-		if _, err := sb.WriteString(flag.LongForm()); err != nil {
+		if err := renderLongFlagName(flag, sb); err != nil {
 			return err
 		}
 
@@ -228,7 +171,7 @@ func (r renderBase) renderShortestFlagLine(flag Flag, sb *bufio.Writer) error {
 			if err := sb.WriteByte(chars.CharEquals); err != nil {
 				return err
 			}
-			if err := renderArgumentName(flag.Argument(), sb, 0); err != nil {
+			if err := renderFlagArgumentNames(flag, sb); err != nil {
 				return err
 			}
 		}
@@ -237,10 +180,25 @@ func (r renderBase) renderShortestFlagLine(flag Flag, sb *bufio.Writer) error {
 }
 
 const (
-	fgDefaultName = "General Flags"
-	fgSingleName  = "Flags"
+	fgDefaultName      = "General Flags"
+	fgSingleName       = "Flags"
+	inheritedFlagsName = "Inherited Flags"
 )
 
+// flagGroupDisplayName returns the name that should be displayed for the given
+// flag group, substituting a generic title for the default group.
+func flagGroupDisplayName(group FlagGroup, multiple bool) string {
+	if group.Name() != chars.DefaultGroupName {
+		return group.Name()
+	}
+
+	if multiple {
+		return fgDefaultName
+	}
+
+	return fgSingleName
+}
+
 func renderFlagGroups(groups []FlagGroup, padding uint8, out *bufio.Writer) error {
 	for i, group := range groups {
 		if i > 0 {
@@ -298,10 +256,25 @@ func renderFlagGroup(
 		}
 	}
 
+	// If the group has a constraint, print a note about it.
+	if group.IsMutuallyExclusive() {
+		if err := out.WriteByte(chars.CharLF); err != nil {
+			return err
+		}
+
+		formatter := chars.NewDescriptionFormatter(chars.DescriptionPadding[padding], chars.HelpTextMaxWidth, out)
+		if err := formatter.Format(flagGroupConstraintNote(group)); err != nil {
+			return err
+		}
+		if err := out.WriteByte(chars.CharLF); err != nil {
+			return err
+		}
+	}
+
 	// Render every flag in the group.
 	for i, flag := range group.Flags() {
 		if i > 0 {
-			if !group.Flags()[i-1].HasDescription() {
+			if !flagHasDescriptionBlock(group.Flags()[i-1]) {
 				if err := out.WriteByte(chars.CharLF); err != nil {
 					return err
 				}
@@ -319,6 +292,83 @@ func renderFlagGroup(
 	return nil
 }
 
+// visibleFlagGroups returns the given flag groups without their hidden flags,
+// leaving out any groups that have no visible flags.
+func visibleFlagGroups(groups []FlagGroup) []FlagGroup {
+	out := make([]FlagGroup, 0, len(groups))
+
+	for _, group := range groups {
+		flags := make([]Flag, 0, len(group.Flags()))
+
+		for _, flag := range group.Flags() {
+			if !flag.IsHidden() {
+				flags = append(flags, flag)
+			}
+		}
+
+		if len(flags) == len(group.Flags()) {
+			out = append(out, group)
+		} else if len(flags) > 0 {
+			visible := *group.(*flagGroup)
+			visible.flags = flags
+			out = append(out, &visible)
+		}
+	}
+
+	return out
+}
+
+// hasVisibleFlagGroups indicates whether any of the given flag groups contain
+// flags that are not hidden.
+func hasVisibleFlagGroups(groups []FlagGroup) bool {
+	return len(visibleFlagGroups(groups)) > 0
+}
+
+// visibleArguments returns the given positional arguments without their hidden
+// arguments.
+func visibleArguments(args []Argument) []Argument {
+	out := make([]Argument, 0, len(args))
+
+	for _, arg := range args {
+		if !arg.IsHidden() {
+			out = append(out, arg)
+		}
+	}
+
+	return out
+}
+
+// visibleCommandGroups returns the given command groups without their hidden
+// subcommands, leaving out any groups that have no visible subcommands.
+func visibleCommandGroups(groups []CommandGroup) []CommandGroup {
+	out := make([]CommandGroup, 0, len(groups))
+
+	for _, group := range groups {
+		branches := make([]CommandBranch, 0, len(group.Branches()))
+		leaves := make([]CommandLeaf, 0, len(group.Leaves()))
+
+		for _, branch := range group.Branches() {
+			if !branch.IsHidden() {
+				branches = append(branches, branch)
+			}
+		}
+
+		for _, leaf := range group.Leaves() {
+			if !leaf.IsHidden() {
+				leaves = append(leaves, leaf)
+			}
+		}
+
+		if len(branches) == len(group.Branches()) && len(leaves) == len(group.Leaves()) {
+			out = append(out, group)
+		} else if len(branches) > 0 || len(leaves) > 0 {
+			out = append(out, commandGroup{group.Name(), group.Description(), branches, leaves})
+		}
+	}
+
+	return out
+}
+
 type flagForms struct {
 	short bool
 	long  bool
@@ -350,7 +400,7 @@ func flattenFlagInheritance(node CommandNode) []flagForms {
 					}
 				}
 
-				if (forms.short || forms.long) && current != node {
+				if (forms.short || forms.long) && current != node && !flag.IsHidden() {
 					options = append(options, forms)
 				}
 			}
@@ -363,27 +413,130 @@ func flattenFlagInheritance(node CommandNode) []flagForms {
 }
 
 func renderInheritedFlag(forms *flagForms, padding uint8, sb *bufio.Writer) error {
+	return renderFlagForms(forms.flag, forms.short, forms.long, padding, sb)
+}
+
+func renderFlagForms(flag Flag, short, long bool, padding uint8, sb *bufio.Writer) error {
 	if _, err := sb.WriteString(chars.HeaderPadding[padding]); err != nil {
 		return err
 	}
 
+	if err := renderFlagNames(flag, short, long, sb); err != nil {
+		return err
+	}
+
+	if flag.HasArgument() {
+		if err := renderArgumentEnvVar(flag.Argument(), sb); err != nil {
+			return err
+		}
+	}
+
+	if flag.HasDescription() {
+		if err := sb.WriteByte(chars.CharLF); err != nil {
+			return err
+		}
+
+		formatter := chars.NewDescriptionFormatter(chars.DescriptionPadding[padding], chars.HelpTextMaxWidth, sb)
+		if err := formatter.Format(flag.Description()); err != nil {
+			return err
+		}
+	}
+
+	for _, note := range flagConstraintNotes(flag) {
+		if err := sb.WriteByte(chars.CharLF); err != nil {
+			return err
+		}
+
+		formatter := chars.NewDescriptionFormatter(chars.DescriptionPadding[padding], chars.HelpTextMaxWidth, sb)
+		if err := formatter.Format(note); err != nil {
+			return err
+		}
+	}
+
+	for i, arg := range flag.Arguments() {
+		if arg.HasDescription() {
+			if err := sb.WriteByte(chars.CharLF); err != nil {
+				return err
+			}
+			if err := renderFlagArgument(arg, padding+1, sb, flagArgumentIndex(flag, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderFlagArgumentNames writes the names of all the arguments of the given
+// flag, separated by spaces, for example: `<old> <new>`.
+func renderFlagArgumentNames(flag Flag, sb *bufio.Writer) error {
+	for i, arg := range flag.Arguments() {
+		if i > 0 {
+			if err := sb.WriteByte(chars.CharSpace); err != nil {
+				return err
+			}
+		}
+
+		if err := renderArgumentName(arg, sb, flagArgumentIndex(flag, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flagArgumentIndex returns the index used to generate a name for the i-th
+// argument of the given flag, if that argument is unnamed.
+//
+// The arguments of flags that take multiple arguments are numbered starting
+// at 1, while the argument of a flag that takes a single argument is not
+// numbered at all.
+func flagArgumentIndex(flag Flag, i int) int {
+	if len(flag.Arguments()) > 1 {
+		return i + 1
+	}
+
+	return 0
+}
+
+// renderLongFlagName writes the long form of the given flag with its leading
+// dashes, including the optional negation prefix for negatable flags, for
+// example: `--[no-]color`.
+func renderLongFlagName(flag Flag, sb *bufio.Writer) error {
+	if _, err := sb.WriteString(chars.StrDoubleDash); err != nil {
+		return err
+	}
+
+	if flag.IsNegatable() {
+		if _, err := sb.WriteString("[" + negatedFlagPrefix + "]"); err != nil {
+			return err
+		}
+	}
+
+	_, err := sb.WriteString(flag.LongForm())
+	return err
+}
+
+// renderFlagNames writes the given forms of the given flag along with their
+// argument names, for example: `-t <arg> | --timeout=<arg>`.
+func renderFlagNames(flag Flag, short, long bool, sb *bufio.Writer) error {
 	// If the flag has a long form name
-	if forms.long {
+	if long {
 
 		// AND a short form character
-		if forms.short {
+		if short {
 			if err := sb.WriteByte(chars.CharDash); err != nil {
 				return err
 			}
-			if err := sb.WriteByte(forms.flag.ShortForm()); err != nil {
+			if err := sb.WriteByte(flag.ShortForm()); err != nil {
 				return err
 			}
 
-			if forms.flag.HasArgument() && flagArgShouldBeRendered(forms.flag.Argument()) {
+			if flag.HasArgument() && flagArgShouldBeRendered(flag.Argument()) {
 				if err := sb.WriteByte(chars.CharSpace); err != nil {
 					return err
 				}
-				if err := renderArgumentName(forms.flag.Argument(), sb, 0); err != nil {
+				if err := renderFlagArgumentNames(flag, sb); err != nil {
 					return err
 				}
 			}
@@ -393,18 +546,15 @@ func renderInheritedFlag(forms *flagForms, padding uint8, sb *bufio.Writer) erro
 			}
 		}
 
-		if _, err := sb.WriteString(chars.StrDoubleDash); err != nil {
-			return err
-		}
-		if _, err := sb.WriteString(forms.flag.LongForm()); err != nil {
+		if err := renderLongFlagName(flag, sb); err != nil {
 			return err
 		}
 
-		if forms.flag.HasArgument() && flagArgShouldBeRendered(forms.flag.Argument()) {
+		if flag.HasArgument() && flagArgShouldBeRendered(flag.Argument()) {
 			if err := sb.WriteByte(chars.CharEquals); err != nil {
 				return err
 			}
-			if err := renderArgumentName(forms.flag.Argument(), sb, 0); err != nil {
+			if err := renderFlagArgumentNames(flag, sb); err != nil {
 				return err
 			}
 		}
@@ -412,39 +562,51 @@ func renderInheritedFlag(forms *flagForms, padding uint8, sb *bufio.Writer) erro
 		if err := sb.WriteByte(chars.CharDash); err != nil {
 			return err
 		}
-		if err := sb.WriteByte(forms.flag.ShortForm()); err != nil {
+		if err := sb.WriteByte(flag.ShortForm()); err != nil {
 			return err
 		}
 
-		if forms.flag.HasArgument() && flagArgShouldBeRendered(forms.flag.Argument()) {
+		if flag.HasArgument() && flagArgShouldBeRendered(flag.Argument()) {
 			if err := sb.WriteByte(chars.CharSpace); err != nil {
 				return err
 			}
-			if err := renderArgumentName(forms.flag.Argument(), sb, 0); err != nil {
+			if err := renderFlagArgumentNames(flag, sb); err != nil {
 				return err
 			}
 		}
 	}
 
-	if forms.flag.HasDescription() {
-		if err := sb.WriteByte(chars.CharLF); err != nil {
-			return err
-		}
+	return nil
+}
 
-		formatter := chars.NewDescriptionFormatter(chars.DescriptionPadding[padding], chars.HelpTextMaxWidth, sb)
-		if err := formatter.Format(forms.flag.Description()); err != nil {
-			return err
-		}
+// flagHasDescriptionBlock tests whether the given flag will have any text
+// rendered beneath its names in help text.
+func flagHasDescriptionBlock(flag Flag) bool {
+	return flag.HasDescription() || len(flag.RequiredFlags()) > 0 || len(flag.ConflictingFlags()) > 0
+}
+
+// flagConstraintNotes returns the lines describing the given flag's
+// relationships with other flags.
+func flagConstraintNotes(flag Flag) []string {
+	out := make([]string, 0, 2)
+
+	if len(flag.RequiredFlags()) > 0 {
+		out = append(out, "Requires: "+strings.Join(flag.RequiredFlags(), ", "))
 	}
 
-	if forms.flag.HasArgument() && forms.flag.Argument().HasDescription() {
-		if err := sb.WriteByte(chars.CharLF); err != nil {
-			return err
-		}
-		if err := renderFlagArgument(forms.flag.Argument(), padding+1, sb); err != nil {
-			return err
-		}
+	if len(flag.ConflictingFlags()) > 0 {
+		out = append(out, "Conflicts with: "+strings.Join(flag.ConflictingFlags(), ", "))
 	}
 
-	return nil
+	return out
+}
+
+// flagGroupConstraintNote returns the note describing the given flag group's
+// constraint.
+func flagGroupConstraintNote(group FlagGroup) string {
+	if group.RequiresExactlyOne() {
+		return "Exactly one of these flags must be used."
+	}
+
+	return "At most one of these flags may be used."
 }
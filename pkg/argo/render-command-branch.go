@@ -37,7 +37,7 @@ func (r comBranchRenderer) renderCommandBranch(branch CommandBranch, out *bufio.
 
 	ha := branch.HasAliases()
 	hd := branch.HasDescription()
-	hf := branch.HasFlagGroups()
+	hf := hasVisibleFlagGroups(branch.FlagGroups())
 
 	if ha {
 		if _, err := out.WriteString(chars.SubLinePadding[0]); err != nil {
@@ -85,7 +85,7 @@ func (r comBranchRenderer) renderCommandBranch(branch CommandBranch, out *bufio.
 		if err := out.WriteByte(chars.CharLF); err != nil {
 			return err
 		}
-		if err := renderFlagGroups(branch.FlagGroups(), 0, out); err != nil {
+		if err := renderFlagGroups(visibleFlagGroups(branch.FlagGroups()), 0, out); err != nil {
 			return err
 		}
 		if err := out.WriteByte(chars.CharLF); err != nil {
@@ -122,7 +122,7 @@ func (r comBranchRenderer) renderCommandBranch(branch CommandBranch, out *bufio.
 		return err
 	}
 
-	if err := renderCommandGroups(branch.CommandGroups(), 0, out); err != nil {
+	if err := renderCommandGroups(visibleCommandGroups(branch.CommandGroups()), 0, out); err != nil {
 		return err
 	}
 
@@ -141,12 +141,12 @@ func (r comBranchRenderer) renderCommandBranchUsage(node CommandBranch, out *buf
 		return err
 	}
 
-	if node.HasFlagGroups() {
+	if hasVisibleFlagGroups(node.FlagGroups()) {
 		hasOptionalFlags := false
 
 		// For all the required flags, append their name (and argument name if
 		// required) to the cli example text.
-		for _, group := range node.FlagGroups() {
+		for _, group := range visibleFlagGroups(node.FlagGroups()) {
 			for _, flag := range group.Flags() {
 				if flag.IsRequired() {
 					if err := out.WriteByte(chars.CharSpace); err != nil {
@@ -71,15 +71,14 @@ func (r renderCommandBase) renderCommandBackHalf(com Command, out *bufio.Writer)
 	// This is calculated ahead of time as it informs whether the flag group
 	// headers should be printed.
 	writeArgs := false
-	if com.HasArguments() {
-		for _, arg := range com.Arguments() {
-			if arg.HasDescription() {
-				writeArgs = true
-			}
+	args := visibleArguments(com.Arguments())
+	for _, arg := range args {
+		if arg.HasDescription() {
+			writeArgs = true
 		}
 	}
 
-	if com.HasFlagGroups() {
+	if hasVisibleFlagGroups(com.FlagGroups()) {
 		if com.HasDescription() {
 			if err := out.WriteByte(chars.CharLF); err != nil {
 				return err
@@ -89,7 +88,7 @@ func (r renderCommandBase) renderCommandBackHalf(com Command, out *bufio.Writer)
 		if err := out.WriteByte(chars.CharLF); err != nil {
 			return err
 		}
-		if err := renderFlagGroups(com.FlagGroups(), 0, out); err != nil {
+		if err := renderFlagGroups(visibleFlagGroups(com.FlagGroups()), 0, out); err != nil {
 			return err
 		}
 	}
@@ -105,9 +104,9 @@ func (r renderCommandBase) renderCommandBackHalf(com Command, out *bufio.Writer)
 			return err
 		}
 
-		multiArgs := len(com.Arguments()) > 1
+		multiArgs := len(args) > 1
 
-		for i, arg := range com.Arguments() {
+		for i, arg := range args {
 			if i > 0 {
 				if err := out.WriteByte(chars.CharLF); err != nil {
 					return err
@@ -133,12 +132,12 @@ func (r renderCommandBase) renderCommandBackHalf(com Command, out *bufio.Writer)
 
 func (r renderCommandBase) renderCommandUsageBackHalf(com Command, out *bufio.Writer) error {
 	// If the command has flag groups
-	if com.HasFlagGroups() {
+	if hasVisibleFlagGroups(com.FlagGroups()) {
 		hasOptionalFlags := false
 
 		// For all the required flags, append their name (and argument name if
 		// required) to the cli example text.
-		for _, group := range com.FlagGroups() {
+		for _, group := range visibleFlagGroups(com.FlagGroups()) {
 			for _, flag := range group.Flags() {
 				if flag.IsRequired() {
 					if err := out.WriteByte(chars.CharSpace); err != nil {
@@ -162,10 +161,10 @@ func (r renderCommandBase) renderCommandUsageBackHalf(com Command, out *bufio.Wr
 	}
 
 	// After all the flag groups have been rendered, append the argument names.
-	if com.HasArguments() {
-		multiArgs := len(com.Arguments()) > 1
+	if args := visibleArguments(com.Arguments()); len(args) > 0 {
+		multiArgs := len(args) > 1
 
-		for i, arg := range com.Arguments() {
+		for i, arg := range args {
 			if err := out.WriteByte(chars.CharSpace); err != nil {
 				return err
 			}
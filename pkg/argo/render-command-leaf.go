@@ -97,15 +97,14 @@ func renderCommandLeafBackHalf(com CommandLeaf, out *bufio.Writer) error {
 	// This is calculated ahead of time as it informs whether the flag group
 	// headers should be printed.
 	writeArgs := false
-	if com.HasArguments() {
-		for _, arg := range com.Arguments() {
-			if arg.HasDescription() {
-				writeArgs = true
-			}
+	args := visibleArguments(com.Arguments())
+	for _, arg := range args {
+		if arg.HasDescription() {
+			writeArgs = true
 		}
 	}
 
-	if com.HasFlagGroups() {
+	if hasVisibleFlagGroups(com.FlagGroups()) {
 		if com.HasDescription() {
 			if err := out.WriteByte(chars.CharLF); err != nil {
 				return err
@@ -115,14 +114,14 @@ func renderCommandLeafBackHalf(com CommandLeaf, out *bufio.Writer) error {
 		if err := out.WriteByte(chars.CharLF); err != nil {
 			return err
 		}
-		if err := renderFlagGroups(com.FlagGroups(), 0, out); err != nil {
+		if err := renderFlagGroups(visibleFlagGroups(com.FlagGroups()), 0, out); err != nil {
 			return err
 		}
 	}
 
 	inherited := flattenFlagInheritance(com)
 	if len(inherited) > 0 {
-		if com.HasFlagGroups() {
+		if hasVisibleFlagGroups(com.FlagGroups()) {
 			if err := out.WriteByte(chars.CharLF); err != nil {
 				return err
 			}
@@ -153,9 +152,9 @@ func renderCommandLeafBackHalf(com CommandLeaf, out *bufio.Writer) error {
 			return err
 		}
 
-		multiArgs := len(com.Arguments()) > 1
+		multiArgs := len(args) > 1
 
-		for i, arg := range com.Arguments() {
+		for i, arg := range args {
 			if i > 0 {
 				if err := out.WriteByte(chars.CharLF); err != nil {
 					return err
@@ -35,7 +35,7 @@ func (r comTreeRenderer) renderCommandTree(tree CommandTree, out *bufio.Writer)
 	}
 
 	hd := tree.HasDescription()
-	hf := tree.HasFlagGroups()
+	hf := hasVisibleFlagGroups(tree.FlagGroups())
 
 	if hd {
 		formatter := chars.NewDescriptionFormatter(chars.DescriptionPadding[0], chars.HelpTextMaxWidth, out)
@@ -51,7 +51,7 @@ func (r comTreeRenderer) renderCommandTree(tree CommandTree, out *bufio.Writer)
 		if err := out.WriteByte(chars.CharLF); err != nil {
 			return err
 		}
-		if err := renderFlagGroups(tree.FlagGroups(), 0, out); err != nil {
+		if err := renderFlagGroups(visibleFlagGroups(tree.FlagGroups()), 0, out); err != nil {
 			return err
 		}
 		if err := out.WriteByte(chars.CharLF); err != nil {
@@ -62,7 +62,7 @@ func (r comTreeRenderer) renderCommandTree(tree CommandTree, out *bufio.Writer)
 	if err := out.WriteByte(chars.CharLF); err != nil {
 		return err
 	}
-	if err := renderCommandGroups(tree.CommandGroups(), 0, out); err != nil {
+	if err := renderCommandGroups(visibleCommandGroups(tree.CommandGroups()), 0, out); err != nil {
 		return err
 	}
 
@@ -84,10 +84,10 @@ func (r comTreeRenderer) renderCommandTreeUsageBlock(tree CommandTree, out *bufi
 		return err
 	}
 
-	if tree.HasFlagGroups() {
+	if hasVisibleFlagGroups(tree.FlagGroups()) {
 		hasOptionalFlags := false
 
-		for _, group := range tree.FlagGroups() {
+		for _, group := range visibleFlagGroups(tree.FlagGroups()) {
 			for _, flag := range group.Flags() {
 				if flag.IsRequired() {
 					if err := out.WriteByte(chars.CharSpace); err != nil {
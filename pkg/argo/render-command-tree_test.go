@@ -215,3 +215,13 @@ func renderOutputCheck[T any](
 		t.Errorf("expected: '%s'\n\ngot: '%s'", expected, buf.String())
 	}
 }
+
+func TestCommandTreeHelpRenderer009(t *testing.T) {
+	com := argo.NewCommandTreeBuilder().
+		WithLeaf(argo.NewCommandLeafBuilder("leaf")).
+		WithLeaf(argo.NewCommandLeafBuilder("secret").Hidden()).
+		WithFlag(argo.NewFlagBuilder().WithLongForm("debug").Hidden()).
+		MustParse([]string{"command", "secret", "--debug"})
+
+	renderOutputCheck(t, output001, com, argo.CommandTreeHelpRenderer())
+}
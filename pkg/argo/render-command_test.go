@@ -199,3 +199,188 @@ func TestCommandHelpRenderer_optionalArgs(t *testing.T) {
 		renderOutputCheck(t, commandHelpRendererExpectOptionalArgs, com, argo.CommandHelpRenderer())
 	}
 }
+
+const commandHelpRendererExpectEnvVar = `Usage:
+  %s [options]
+
+Flags
+  -t <arg> | --timeout=<arg>  [env: APP_TIMEOUT]
+      Request timeout.
+  -h | --help
+      Prints this help text.
+`
+
+func TestCommandHelpRenderer_envVar(t *testing.T) {
+	var bind string
+	com, err := cli.Command().
+		WithFlag(cli.ComboFlag('t', "timeout").
+			WithDescription("Request timeout.").
+			WithBinding(&bind, true).
+			WithEnvVar("APP_TIMEOUT")).
+		Build(nil)
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	} else {
+		renderOutputCheck(t, commandHelpRendererExpectEnvVar, com, argo.CommandHelpRenderer())
+	}
+}
+
+const commandHelpRendererExpectConstraints = `Usage:
+  %s [options]
+
+Output
+    At most one of these flags may be used.
+
+  --json
+      Print JSON.
+      Conflicts with: --yaml
+  --yaml
+
+Help Flags
+  -h | --help
+      Prints this help text.
+`
+
+func TestCommandHelpRenderer_constraints(t *testing.T) {
+	com, err := cli.Command().
+		WithFlagGroup(cli.FlagGroup("Output").
+			MutuallyExclusive().
+			WithFlag(cli.LongFlag("json").
+				WithDescription("Print JSON.").
+				ConflictsWith("yaml")).
+			WithFlag(cli.LongFlag("yaml"))).
+		Build(nil)
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	} else {
+		renderOutputCheck(t, commandHelpRendererExpectConstraints, com, argo.CommandHelpRenderer())
+	}
+}
+
+const commandHelpRendererExpectVariadic = `Usage:
+  %s [options] <files...>
+
+Flags
+  -h | --help
+      Prints this help text.
+
+Arguments
+  <files...>
+      Files to remove.
+`
+
+func TestCommandHelpRenderer_variadic(t *testing.T) {
+	var files []string
+	com, err := cli.Command().
+		WithArgument(cli.Argument().
+			WithName("files").
+			WithDescription("Files to remove.").
+			WithBinding(&files).
+			Require()).
+		Build(nil)
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	} else {
+		renderOutputCheck(t, commandHelpRendererExpectVariadic, com, argo.CommandHelpRenderer())
+	}
+}
+
+const commandHelpRendererExpectNegatable = `Usage:
+  %s [options]
+
+Flags
+  --[no-]color
+      Colorize output.
+  -h | --help
+      Prints this help text.
+`
+
+func TestCommandHelpRenderer_negatable(t *testing.T) {
+	var color bool
+	com, err := cli.Command().
+		WithFlag(cli.LongFlag("color").
+			WithDescription("Colorize output.").
+			WithBinding(&color, false).
+			Negatable()).
+		Build(nil)
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	} else {
+		renderOutputCheck(t, commandHelpRendererExpectNegatable, com, argo.CommandHelpRenderer())
+	}
+}
+
+const commandHelpRendererExpectMultiArgument = `Usage:
+  %s [options]
+
+Flags
+  -r <old> <new> | --rename=<old> <new>
+      Rename a file.
+  -p <arg1> <arg2> <arg3> | --point=<arg1> <arg2> <arg3>
+      Set a point.
+  -h | --help
+      Prints this help text.
+`
+
+func TestCommandHelpRenderer_multiArgument(t *testing.T) {
+	var oldName, newName string
+	var point [3]int
+	com, err := cli.Command().
+		WithFlag(cli.Flag().
+			WithShortForm('r').
+			WithLongForm("rename").
+			WithDescription("Rename a file.").
+			WithArguments(
+				cli.Argument().WithName("old").WithBinding(&oldName),
+				cli.Argument().WithName("new").WithBinding(&newName))).
+		WithFlag(cli.Flag().
+			WithShortForm('p').
+			WithLongForm("point").
+			WithDescription("Set a point.").
+			WithArguments(
+				cli.Argument().WithBinding(&point[0]),
+				cli.Argument().WithBinding(&point[1]),
+				cli.Argument().WithBinding(&point[2]))).
+		Build(nil)
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	} else {
+		renderOutputCheck(t, commandHelpRendererExpectMultiArgument, com, argo.CommandHelpRenderer())
+	}
+}
+
+const commandHelpRendererExpectHiddenArgument = `Usage:
+  %s [options] <file>
+
+Flags
+  -h | --help
+      Prints this help text.
+
+Arguments
+  <file>
+      File to read.
+`
+
+func TestCommandHelpRenderer_hiddenArgument(t *testing.T) {
+	com, err := cli.Command().
+		WithArgument(cli.Argument().
+			WithName("file").
+			WithDescription("File to read.").
+			Require()).
+		WithArgument(cli.Argument().
+			WithName("mode").
+			WithDescription("Legacy read mode.").
+			Hidden()).
+		Build(nil)
+
+	if err != nil {
+		t.Error("expected err to be nil but was", err)
+	} else {
+		renderOutputCheck(t, commandHelpRendererExpectHiddenArgument, com, argo.CommandHelpRenderer())
+	}
+}
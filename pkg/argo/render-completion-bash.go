@@ -0,0 +1,84 @@
+package argo
+
+import (
+	"strings"
+)
+
+func renderBashCompletion(root *completionNode, sb *strings.Builder) {
+	fn := "_" + completionFuncName(root.name) + "_complete"
+
+	sb.WriteString("# bash completion for " + root.name + "\n\n")
+	sb.WriteString(fn + "() {\n")
+	sb.WriteString("    local cur prev node word i\n")
+	sb.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	sb.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	sb.WriteString("    node=" + singleQuote(root.path) + "\n")
+	sb.WriteString("    COMPREPLY=()\n")
+
+	// Walk the typed words to determine which node of the tree the cursor is
+	// positioned under, skipping the values of flags that require an argument.
+	// Bash splits "--flag=value" into three words, so a lone "=" following such
+	// a flag is skipped along with the value.
+	if len(root.children) > 0 {
+		sb.WriteString("\n    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+		sb.WriteString("        word=\"${COMP_WORDS[i]}\"\n")
+		sb.WriteString("        case \"${node}:${word}\" in\n")
+
+		root.walk(func(node *completionNode) {
+			if argFlags := node.argFlagWords(); len(node.children) > 0 && len(argFlags) > 0 {
+				sb.WriteString("            ")
+				for i, word := range argFlags {
+					if i > 0 {
+						sb.WriteByte('|')
+					}
+					sb.WriteString(singleQuote(node.path + ":" + word))
+				}
+				sb.WriteString(")\n")
+				sb.WriteString("                ((i++))\n")
+				sb.WriteString("                [[ \"${COMP_WORDS[i]}\" == \"=\" ]] && ((i++))\n")
+				sb.WriteString("                ;;\n")
+			}
+
+			for _, child := range node.children {
+				sb.WriteString("            ")
+				for i, word := range append([]string{child.name}, child.aliases...) {
+					if i > 0 {
+						sb.WriteByte('|')
+					}
+					sb.WriteString(singleQuote(node.path + ":" + word))
+				}
+				sb.WriteString(") node=" + singleQuote(child.path) + " ;;\n")
+			}
+		})
+
+		sb.WriteString("        esac\n")
+		sb.WriteString("    done\n")
+	}
+
+	sb.WriteString("\n    case \"${node}\" in\n")
+
+	root.walk(func(node *completionNode) {
+		sb.WriteString("        " + singleQuote(node.path) + ")\n")
+
+		if argFlags := node.argFlagWords(); len(argFlags) > 0 {
+			sb.WriteString("            case \"${prev}\" in\n")
+			sb.WriteString("                " + strings.Join(argFlags, "|") + ") return ;;\n")
+			sb.WriteString("            esac\n")
+		}
+
+		sb.WriteString("            if [[ \"${cur}\" == -* ]]; then\n")
+		sb.WriteString("                COMPREPLY=( $(compgen -W " + singleQuote(strings.Join(node.flagWords(), " ")) + " -- \"${cur}\") )\n")
+
+		if !node.leaf {
+			sb.WriteString("            else\n")
+			sb.WriteString("                COMPREPLY=( $(compgen -W " + singleQuote(strings.Join(node.childWords(), " ")) + " -- \"${cur}\") )\n")
+		}
+
+		sb.WriteString("            fi\n")
+		sb.WriteString("            ;;\n")
+	})
+
+	sb.WriteString("    esac\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("complete -o default -F " + fn + " " + root.name + "\n")
+}
@@ -0,0 +1,104 @@
+package argo
+
+import (
+	"strings"
+)
+
+func renderFishCompletion(root *completionNode, sb *strings.Builder) {
+	fn := "__" + completionFuncName(root.name)
+
+	sb.WriteString("# fish completion for " + root.name + "\n\n")
+
+	sb.WriteString("function " + fn + "_node\n")
+	sb.WriteString("    set -l node " + fishQuote(root.path) + "\n")
+
+	// Walk the typed words to determine which node of the tree the cursor is
+	// positioned under, skipping the values of flags that require an argument.
+	if len(root.children) > 0 {
+		sb.WriteString("    set -l skip 0\n")
+		sb.WriteString("    for word in (commandline -opc)[2..-1]\n")
+		sb.WriteString("        if test $skip = 1\n")
+		sb.WriteString("            set skip 0\n")
+		sb.WriteString("            continue\n")
+		sb.WriteString("        end\n")
+		sb.WriteString("        switch \"$node:$word\"\n")
+
+		root.walk(func(node *completionNode) {
+			if argFlags := node.argFlagWords(); len(node.children) > 0 && len(argFlags) > 0 {
+				sb.WriteString("            case")
+				for _, word := range argFlags {
+					sb.WriteString(" " + fishQuote(node.path+":"+word))
+				}
+				sb.WriteString("\n                set skip 1\n")
+			}
+
+			for _, child := range node.children {
+				sb.WriteString("            case")
+				for _, word := range append([]string{child.name}, child.aliases...) {
+					sb.WriteString(" " + fishQuote(node.path+":"+word))
+				}
+				sb.WriteString("\n                set node " + fishQuote(child.path) + "\n")
+			}
+		})
+
+		sb.WriteString("        end\n")
+		sb.WriteString("    end\n")
+	}
+
+	sb.WriteString("    echo $node\n")
+	sb.WriteString("end\n\n")
+
+	sb.WriteString("function " + fn + "_node_is\n")
+	sb.WriteString("    test (" + fn + "_node) = \"$argv[1]\"\n")
+	sb.WriteString("end\n")
+
+	root.walk(func(node *completionNode) {
+		cond := " -n " + fishQuote(fn+"_node_is "+fishQuote(node.path))
+
+		sb.WriteString("\n")
+
+		if !node.leaf {
+			sb.WriteString("complete -c " + root.name + cond + " -f\n")
+		}
+
+		for _, child := range node.children {
+			for _, word := range append([]string{child.name}, child.aliases...) {
+				sb.WriteString("complete -c " + root.name + cond + " -a " + fishQuote(word))
+				if len(child.desc) > 0 {
+					sb.WriteString(" -d " + fishQuote(child.desc))
+				}
+				sb.WriteByte('\n')
+			}
+		}
+
+		for _, flag := range node.flags {
+			sb.WriteString("complete -c " + root.name + cond)
+			if flag.short != 0 {
+				sb.WriteString(" -s " + string(flag.short))
+			}
+			if len(flag.long) > 0 {
+				sb.WriteString(" -l " + flag.long)
+			}
+			if flag.takesArg {
+				sb.WriteString(" -r")
+			}
+			if len(flag.desc) > 0 {
+				sb.WriteString(" -d " + fishQuote(flag.desc))
+			}
+			sb.WriteByte('\n')
+
+			if flag.negatable {
+				sb.WriteString("complete -c " + root.name + cond + " -l " + negatedFlagPrefix + flag.long)
+				if len(flag.desc) > 0 {
+					sb.WriteString(" -d " + fishQuote(flag.desc))
+				}
+				sb.WriteByte('\n')
+			}
+		}
+	})
+}
+
+// fishQuote wraps the given string in fish shell single quotes.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
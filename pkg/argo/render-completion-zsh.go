@@ -0,0 +1,118 @@
+package argo
+
+import (
+	"strings"
+)
+
+func renderZshCompletion(root *completionNode, sb *strings.Builder) {
+	fn := "_" + completionFuncName(root.name)
+
+	sb.WriteString("#compdef " + root.name + "\n\n")
+	sb.WriteString(fn + "() {\n")
+	sb.WriteString("    local node i\n")
+	sb.WriteString("    local -a opts cmds argopts\n")
+	sb.WriteString("    node=" + singleQuote(root.path) + "\n")
+
+	// Walk the typed words to determine which node of the tree the cursor is
+	// positioned under, skipping the values of flags that require an argument.
+	if len(root.children) > 0 {
+		sb.WriteString("\n    for ((i = 2; i < CURRENT; i++)); do\n")
+		sb.WriteString("        case \"${node}:${words[i]}\" in\n")
+
+		root.walk(func(node *completionNode) {
+			if argFlags := node.argFlagWords(); len(node.children) > 0 && len(argFlags) > 0 {
+				sb.WriteString("            ")
+				for i, word := range argFlags {
+					if i > 0 {
+						sb.WriteByte('|')
+					}
+					sb.WriteString(singleQuote(node.path + ":" + word))
+				}
+				sb.WriteString(") (( i++ )) ;;\n")
+			}
+
+			for _, child := range node.children {
+				sb.WriteString("            ")
+				for i, word := range append([]string{child.name}, child.aliases...) {
+					if i > 0 {
+						sb.WriteByte('|')
+					}
+					sb.WriteString(singleQuote(node.path + ":" + word))
+				}
+				sb.WriteString(") node=" + singleQuote(child.path) + " ;;\n")
+			}
+		})
+
+		sb.WriteString("        esac\n")
+		sb.WriteString("    done\n")
+	}
+
+	sb.WriteString("\n    case \"${node}\" in\n")
+
+	root.walk(func(node *completionNode) {
+		sb.WriteString("        " + singleQuote(node.path) + ")\n")
+
+		sb.WriteString("            opts=(")
+		for _, flag := range node.flags {
+			if flag.short != 0 {
+				sb.WriteString("\n                " + singleQuote(zshDescribeEntry("-"+string(flag.short), flag.desc)))
+			}
+			if len(flag.long) > 0 {
+				sb.WriteString("\n                " + singleQuote(zshDescribeEntry("--"+flag.long, flag.desc)))
+			}
+			if flag.negatable {
+				sb.WriteString("\n                " + singleQuote(zshDescribeEntry("--"+negatedFlagPrefix+flag.long, flag.desc)))
+			}
+		}
+		sb.WriteString(")\n")
+
+		sb.WriteString("            cmds=(")
+		for _, child := range node.children {
+			for _, word := range append([]string{child.name}, child.aliases...) {
+				sb.WriteString("\n                " + singleQuote(zshDescribeEntry(word, child.desc)))
+			}
+		}
+		sb.WriteString(")\n")
+
+		sb.WriteString("            argopts=(")
+		for i, word := range node.argFlagWords() {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(singleQuote(word))
+		}
+		sb.WriteString(")\n")
+		sb.WriteString("            ;;\n")
+	})
+
+	sb.WriteString("    esac\n\n")
+
+	sb.WriteString("    if (( ${argopts[(Ie)${words[CURRENT-1]}]} )); then\n")
+	sb.WriteString("        _files\n")
+	sb.WriteString("    elif [[ \"${words[CURRENT]}\" == -* ]]; then\n")
+	sb.WriteString("        _describe -t options 'option' opts\n")
+	sb.WriteString("    elif (( ${#cmds} )); then\n")
+	sb.WriteString("        _describe -t commands 'command' cmds\n")
+	sb.WriteString("    else\n")
+	sb.WriteString("        _files\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("if [ \"$funcstack[1]\" = \"" + fn + "\" ]; then\n")
+	sb.WriteString("    " + fn + " \"$@\"\n")
+	sb.WriteString("else\n")
+	sb.WriteString("    compdef " + fn + " " + root.name + "\n")
+	sb.WriteString("fi\n")
+}
+
+// zshDescribeEntry formats a value and description into an entry suitable for
+// use with the zsh _describe function.
+func zshDescribeEntry(value, desc string) string {
+	value = strings.ReplaceAll(value, ":", `\:`)
+
+	if len(desc) == 0 {
+		return value
+	}
+
+	return value + ":" + desc
+}
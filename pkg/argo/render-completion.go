@@ -0,0 +1,268 @@
+package argo
+
+import (
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+)
+
+// Shell identifies a command line shell for which a completion script may be
+// rendered.
+type Shell uint8
+
+const (
+	ShellBash Shell = iota
+	ShellZsh
+	ShellFish
+)
+
+func (s Shell) String() string {
+	switch s {
+	case ShellBash:
+		return "bash"
+	case ShellZsh:
+		return "zsh"
+	case ShellFish:
+		return "fish"
+	default:
+		return "invalid"
+	}
+}
+
+// CommandCompletionRenderer returns a CompletionRenderer instance that renders
+// completion scripts for the given shell for Command instances.
+func CommandCompletionRenderer(shell Shell) CompletionRenderer[Command] {
+	return comCompletionRenderer{shell}
+}
+
+// CommandTreeCompletionRenderer returns a CompletionRenderer instance that
+// renders completion scripts for the given shell for CommandTree instances.
+func CommandTreeCompletionRenderer(shell Shell) CompletionRenderer[CommandTree] {
+	return comTreeCompletionRenderer{shell}
+}
+
+type comCompletionRenderer struct{ shell Shell }
+
+func (r comCompletionRenderer) RenderCompletion(command Command, writer io.Writer) error {
+	return renderCompletion(r.shell, newCommandCompletionNode(command), writer)
+}
+
+type comTreeCompletionRenderer struct{ shell Shell }
+
+func (r comTreeCompletionRenderer) RenderCompletion(tree CommandTree, writer io.Writer) error {
+	return renderCompletion(r.shell, newCommandNodeCompletionNode(tree, nil), writer)
+}
+
+func renderCompletion(shell Shell, root *completionNode, writer io.Writer) error {
+	sb := new(strings.Builder)
+
+	switch shell {
+	case ShellBash:
+		renderBashCompletion(root, sb)
+	case ShellZsh:
+		renderZshCompletion(root, sb)
+	case ShellFish:
+		renderFishCompletion(root, sb)
+	default:
+		panic("illegal state: unrecognized shell")
+	}
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+// completionNode is a shell-agnostic view of a single command in a command
+// tree containing only the information needed to render completion scripts.
+type completionNode struct {
+	// path is the space separated list of command names from the root of the
+	// tree to this node, used as the node's identifier in rendered scripts.
+	path     string
+	name     string
+	aliases  []string
+	desc     string
+	flags    []completionFlag
+	children []*completionNode
+	leaf     bool
+}
+
+type completionFlag struct {
+	short     byte
+	long      string
+	desc      string
+	takesArg  bool
+	negatable bool
+}
+
+func newCommandCompletionNode(com Command) *completionNode {
+	out := &completionNode{
+		path: com.Name(),
+		name: com.Name(),
+		desc: completionDescription(com.Description()),
+		leaf: true,
+	}
+
+	for _, group := range visibleFlagGroups(com.FlagGroups()) {
+		for _, flag := range group.Flags() {
+			out.flags = append(out.flags, newCompletionFlag(flag, true, true))
+		}
+	}
+
+	return out
+}
+
+func newCommandNodeCompletionNode(node CommandNode, parent *completionNode) *completionNode {
+	out := &completionNode{
+		name: node.Name(),
+		desc: completionDescription(node.Description()),
+	}
+
+	if parent == nil {
+		out.path = node.Name()
+	} else {
+		out.path = parent.path + " " + node.Name()
+	}
+
+	if child, ok := node.(CommandChild); ok {
+		out.aliases = child.Aliases()
+	}
+
+	for _, group := range visibleFlagGroups(node.FlagGroups()) {
+		for _, flag := range group.Flags() {
+			out.flags = append(out.flags, newCompletionFlag(flag, true, true))
+		}
+	}
+
+	for _, forms := range flattenFlagInheritance(node) {
+		out.flags = append(out.flags, newCompletionFlag(forms.flag, forms.short, forms.long))
+	}
+
+	if p, ok := node.(CommandParent); ok {
+		for _, group := range visibleCommandGroups(p.CommandGroups()) {
+			for _, branch := range group.Branches() {
+				out.children = append(out.children, newCommandNodeCompletionNode(branch, out))
+			}
+			for _, leaf := range group.Leaves() {
+				out.children = append(out.children, newCommandNodeCompletionNode(leaf, out))
+			}
+		}
+
+		slices.SortFunc(out.children, func(a, b *completionNode) int { return strings.Compare(a.name, b.name) })
+	} else {
+		out.leaf = true
+	}
+
+	return out
+}
+
+func newCompletionFlag(flag Flag, short, long bool) completionFlag {
+	out := completionFlag{
+		desc:     completionDescription(flag.Description()),
+		takesArg: flag.RequiresArgument(),
+	}
+
+	if short && flag.HasShortForm() {
+		out.short = flag.ShortForm()
+	}
+
+	if long && flag.HasLongForm() {
+		out.long = flag.LongForm()
+		out.negatable = flag.IsNegatable()
+	}
+
+	return out
+}
+
+// walk calls the given function on this node and all of its descendants,
+// depth first.
+func (c *completionNode) walk(fn func(node *completionNode)) {
+	fn(c)
+
+	for _, child := range c.children {
+		child.walk(fn)
+	}
+}
+
+// flagWords returns all the flag forms usable at this node, for example "-v"
+// and "--verbose".
+func (c *completionNode) flagWords() []string {
+	out := make([]string, 0, len(c.flags)*2)
+
+	for _, flag := range c.flags {
+		if flag.short != 0 {
+			out = append(out, "-"+string(flag.short))
+		}
+		if len(flag.long) > 0 {
+			out = append(out, "--"+flag.long)
+		}
+		if flag.negatable {
+			out = append(out, "--"+negatedFlagPrefix+flag.long)
+		}
+	}
+
+	return out
+}
+
+// argFlagWords returns the flag forms usable at this node that require an
+// argument.
+func (c *completionNode) argFlagWords() []string {
+	out := make([]string, 0, len(c.flags))
+
+	for _, flag := range c.flags {
+		if !flag.takesArg {
+			continue
+		}
+		if flag.short != 0 {
+			out = append(out, "-"+string(flag.short))
+		}
+		if len(flag.long) > 0 {
+			out = append(out, "--"+flag.long)
+		}
+	}
+
+	return out
+}
+
+// childWords returns the names and aliases of this node's children.
+func (c *completionNode) childWords() []string {
+	out := make([]string, 0, len(c.children))
+
+	for _, child := range c.children {
+		out = append(out, child.name)
+		out = append(out, child.aliases...)
+	}
+
+	return out
+}
+
+// completionDescription collapses the given description down to its first line
+// for use in completion menus.
+func completionDescription(desc string) string {
+	desc = strings.TrimSpace(desc)
+
+	if idx := strings.IndexByte(desc, '\n'); idx > -1 {
+		desc = strings.TrimSpace(desc[:idx])
+	}
+
+	return desc
+}
+
+// completionFuncName converts the given program name into a value that is safe
+// to use as part of a shell function name.
+func completionFuncName(name string) string {
+	out := []byte(name)
+
+	for i := range out {
+		if !chars.IsAlphanumeric(out[i]) {
+			out[i] = '_'
+		}
+	}
+
+	return string(out)
+}
+
+// singleQuote wraps the given string in POSIX shell single quotes.
+func singleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,129 @@
+package argo_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+func completionCheck(t *testing.T, output string, expected ...string) {
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("expected completion script to contain %q but it did not:\n%s", exp, output)
+		}
+	}
+}
+
+// Bash completion walks the tree and offers inherited flags.
+func TestCommandTreeCompletionRenderer_bash(t *testing.T) {
+	sb := new(strings.Builder)
+
+	if err := argo.CommandTreeCompletionRenderer(argo.ShellBash).RenderCompletion(renderTestTree(), sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	name := commandName
+
+	completionCheck(t, sb.String(),
+		"'"+name+" files:remove'|'"+name+" files:rm') node='"+name+" files remove'",
+		"--output",
+		"--verbose",
+		"complete -o default -F",
+	)
+}
+
+// Bash completion skips the values of flags that require an argument when
+// working out the current node.
+func TestCommandTreeCompletionRenderer_bashFlagValues(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not available")
+	}
+
+	sb := new(strings.Builder)
+
+	if err := argo.CommandTreeCompletionRenderer(argo.ShellBash).RenderCompletion(renderTestTree(), sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	tests := []struct {
+		words    string
+		expected string
+	}{
+		{"--config build ''", "build files"},
+		{"--config = build ''", "build files"},
+		{"files --config remove ''", "remove rm"},
+		{"files ''", "remove rm"},
+	}
+
+	for _, test := range tests {
+		script := sb.String() + `
+fn="$(complete -p ` + commandName + `)"
+fn="${fn#*-F }"
+COMP_WORDS=(` + commandName + ` ` + test.words + `)
+COMP_CWORD=$(( ${#COMP_WORDS[@]} - 1 ))
+"${fn%% *}"
+echo "${COMPREPLY[*]}"
+`
+
+		out, err := exec.Command(bash, "-c", script).CombinedOutput()
+		if err != nil {
+			t.Fatal("expected err to be nil but was", err, string(out))
+		}
+
+		if strings.TrimSpace(string(out)) != test.expected {
+			t.Errorf("expected completions for %s to be %q but was %q", test.words, test.expected, out)
+		}
+	}
+}
+
+// Zsh completion includes descriptions.
+func TestCommandTreeCompletionRenderer_zsh(t *testing.T) {
+	sb := new(strings.Builder)
+
+	if err := argo.CommandTreeCompletionRenderer(argo.ShellZsh).RenderCompletion(renderTestTree(), sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	completionCheck(t, sb.String(),
+		"#compdef "+commandName,
+		"'--verbose:Be loud.'",
+		"'files:File operations.'",
+		"'build:Builds things.'",
+		"'rm:Removes files.'",
+		"'"+commandName+":--config') (( i++ )) ;;",
+	)
+}
+
+// Fish completion marks flags requiring arguments.
+func TestCommandTreeCompletionRenderer_fish(t *testing.T) {
+	sb := new(strings.Builder)
+
+	if err := argo.CommandTreeCompletionRenderer(argo.ShellFish).RenderCompletion(renderTestTree(), sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	completionCheck(t, sb.String(),
+		"-l output -r",
+		"-s v -l verbose -d 'Be loud.'",
+		"-a 'rm'",
+		"case '"+commandName+":--config'\n                set skip 1\n",
+	)
+}
+
+// Single commands complete their flags.
+func TestCommandCompletionRenderer_bash(t *testing.T) {
+	com := argo.NewCommandBuilder().
+		WithFlag(argo.NewFlagBuilder().WithShortForm('q').WithLongForm("quiet")).
+		MustParse([]string{"command"})
+
+	sb := new(strings.Builder)
+
+	if err := argo.CommandCompletionRenderer(argo.ShellBash).RenderCompletion(com, sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	completionCheck(t, sb.String(), "-q", "--quiet", "--help")
+}
@@ -0,0 +1,415 @@
+package argo
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+	"github.com/Foxcapades/Argonaut/internal/util"
+)
+
+// DocFormat identifies a markup format that command tree reference documents
+// may be exported in.
+type DocFormat uint8
+
+const (
+	DocFormatMarkdown DocFormat = iota
+	DocFormatAsciiDoc
+)
+
+func (f DocFormat) String() string {
+	switch f {
+	case DocFormatMarkdown:
+		return "markdown"
+	case DocFormatAsciiDoc:
+		return "asciidoc"
+	default:
+		return "invalid"
+	}
+}
+
+// A DocExporter is a type that exports reference documentation for every node
+// in a CommandTree.
+type DocExporter interface {
+
+	// ExportDocs writes one document for every node in the given command tree to
+	// the given directory.
+	//
+	// Each document is named for the full path to its node joined with dashes,
+	// for example `app-branch-leaf.md`.
+	ExportDocs(tree CommandTree, dir string) error
+}
+
+// CommandTreeDocExporter returns a DocExporter instance that exports documents
+// in the given format.
+//
+// The usage lines, flag names, and argument names in the exported documents
+// are rendered by the same code that renders help text, so the documents will
+// always match the output of `--help`.
+func CommandTreeDocExporter(format DocFormat) DocExporter {
+	switch format {
+	case DocFormatMarkdown:
+		return comTreeDocExporter{markdownDocFormat{}}
+	case DocFormatAsciiDoc:
+		return comTreeDocExporter{asciiDocFormat{}}
+	default:
+		panic("illegal state: unrecognized doc format")
+	}
+}
+
+type comTreeDocExporter struct{ format docFormat }
+
+func (e comTreeDocExporter) ExportDocs(tree CommandTree, dir string) error {
+	return e.exportNode(tree, dir)
+}
+
+func (e comTreeDocExporter) exportNode(node CommandNode, dir string) error {
+	sb := new(strings.Builder)
+
+	if err := renderDocNode(node, e.format, sb); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, docFileName(node, e.format)), []byte(sb.String()), 0644); err != nil {
+		return err
+	}
+
+	if parent, ok := node.(CommandParent); ok {
+		for _, group := range visibleCommandGroups(parent.CommandGroups()) {
+			for _, child := range sortedCommandGroupChildren(group) {
+				if err := e.exportNode(child, dir); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func docFileName(node CommandNode, format docFormat) string {
+	return strings.Join(commandPath(node), "-") + format.extension()
+}
+
+func renderDocNode(node CommandNode, format docFormat, sb *strings.Builder) error {
+	format.heading(1, strings.Join(commandPath(node), " "), sb)
+
+	if node.HasDescription() {
+		format.paragraph(node.Description(), sb)
+	}
+
+	usage, err := renderDocUsage(node)
+	if err != nil {
+		return err
+	}
+
+	format.heading(2, "Usage", sb)
+	format.codeBlock(usage, sb)
+
+	if child, ok := node.(CommandChild); ok && child.HasAliases() {
+		aliases := slices.Clone(child.Aliases())
+		slices.Sort(aliases)
+
+		for i := range aliases {
+			aliases[i] = format.code(aliases[i])
+		}
+
+		format.paragraph("Aliases: "+strings.Join(aliases, ", "), sb)
+	}
+
+	if hasVisibleFlagGroups(node.FlagGroups()) {
+		format.heading(2, fgSingleName, sb)
+
+		groups := visibleFlagGroups(node.FlagGroups())
+		multiple := len(groups) > 1
+		for _, group := range groups {
+			if multiple || group.Name() != chars.DefaultGroupName {
+				format.heading(3, flagGroupDisplayName(group, multiple), sb)
+			}
+
+			if group.HasDescription() {
+				format.paragraph(group.Description(), sb)
+			}
+
+			if group.IsMutuallyExclusive() {
+				format.paragraph(flagGroupConstraintNote(group), sb)
+			}
+
+			for _, flag := range group.Flags() {
+				if err := renderDocFlag(flag, flag.HasShortForm(), flag.HasLongForm(), format, sb); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if inherited := flattenFlagInheritance(node); len(inherited) > 0 {
+		format.heading(2, inheritedFlagsName, sb)
+
+		for i := range inherited {
+			if err := renderDocFlag(inherited[i].flag, inherited[i].short, inherited[i].long, format, sb); err != nil {
+				return err
+			}
+		}
+	}
+
+	var args []Argument
+	if com, ok := node.(CommandLeaf); ok {
+		args = visibleArguments(com.Arguments())
+	}
+
+	if len(args) > 0 {
+		format.heading(2, comArgs, sb)
+
+		multiArgs := len(args) > 1
+		for i, arg := range args {
+			name, err := renderToString(func(out *bufio.Writer) error {
+				return renderArgumentName(arg, out, util.IfElse(multiArgs, i+1, 0))
+			})
+			if err != nil {
+				return err
+			}
+
+			format.item(name, docArgumentDescription(arg, format), sb)
+		}
+	}
+
+	if parent, ok := node.(CommandParent); ok {
+		format.heading(2, defaultComGroupName, sb)
+
+		groups := visibleCommandGroups(parent.CommandGroups())
+		multiple := len(groups) > 1
+		for _, group := range groups {
+			if multiple || group.Name() != chars.DefaultGroupName {
+				if group.Name() == chars.DefaultGroupName {
+					format.heading(3, defaultComGroupName, sb)
+				} else {
+					format.heading(3, group.Name(), sb)
+				}
+			}
+
+			if group.HasDescription() {
+				format.paragraph(group.Description(), sb)
+			}
+
+			for _, child := range sortedCommandGroupChildren(group) {
+				format.link(child.Name(), docFileName(child, format), completionDescription(child.Description()), sb)
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderDocUsage renders the usage line for the given node using the help text
+// renderers.
+func renderDocUsage(node CommandNode) (string, error) {
+	usage, err := renderToString(func(out *bufio.Writer) error {
+		switch n := node.(type) {
+		case CommandTree:
+			return comTreeRenderer{}.renderCommandTreeUsageBlock(n, out)
+		case CommandBranch:
+			return comBranchRenderer{}.renderCommandBranchUsage(n, out)
+		case CommandLeaf:
+			return comLeafRenderer{}.renderCommandLeafUsage(n, out)
+		default:
+			panic("illegal state: unrecognized command node type")
+		}
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(usage, comPrefix), chars.SubLinePadding[0]), nil
+}
+
+func renderDocFlag(flag Flag, short, long bool, format docFormat, sb *strings.Builder) error {
+	names, err := renderToString(func(out *bufio.Writer) error {
+		return renderFlagNames(flag, short, long, out)
+	})
+	if err != nil {
+		return err
+	}
+
+	desc := make([]string, 0, 3)
+
+	if flag.HasDescription() {
+		desc = append(desc, strings.TrimSpace(flag.Description()))
+	}
+
+	for _, arg := range flag.Arguments() {
+		desc = append(desc, docArgumentDescription(arg, format)...)
+	}
+
+	desc = append(desc, flagConstraintNotes(flag)...)
+
+	format.item(names, desc, sb)
+
+	return nil
+}
+
+func docArgumentDescription(arg Argument, format docFormat) []string {
+	out := make([]string, 0, 2)
+
+	if arg.HasDescription() {
+		out = append(out, strings.TrimSpace(arg.Description()))
+	}
+
+	if arg.HasEnvVar() {
+		out = append(out, "Environment variable: "+format.code(arg.EnvVar()))
+	}
+
+	return out
+}
+
+// renderToString calls the given render function with a writer that collects
+// its output into a string.
+func renderToString(fn func(out *bufio.Writer) error) (string, error) {
+	sb := new(strings.Builder)
+	buf := bufio.NewWriter(sb)
+
+	if err := fn(buf); err != nil {
+		return "", err
+	}
+
+	if err := buf.Flush(); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// docFormat defines the markup used when exporting documents.
+type docFormat interface {
+	extension() string
+	heading(level int, text string, sb *strings.Builder)
+	paragraph(text string, sb *strings.Builder)
+	codeBlock(text string, sb *strings.Builder)
+	code(text string) string
+	item(term string, desc []string, sb *strings.Builder)
+	link(text, target, desc string, sb *strings.Builder)
+}
+
+type markdownDocFormat struct{}
+
+func (markdownDocFormat) extension() string {
+	return ".md"
+}
+
+func (markdownDocFormat) heading(level int, text string, sb *strings.Builder) {
+	sb.WriteString(strings.Repeat("#", level))
+	sb.WriteByte(chars.CharSpace)
+	sb.WriteString(text)
+	sb.WriteString("\n\n")
+}
+
+func (markdownDocFormat) paragraph(text string, sb *strings.Builder) {
+	sb.WriteString(strings.TrimSpace(text))
+	sb.WriteString("\n\n")
+}
+
+func (markdownDocFormat) codeBlock(text string, sb *strings.Builder) {
+	sb.WriteString("```\n")
+	sb.WriteString(text)
+	sb.WriteString("\n```\n\n")
+}
+
+func (markdownDocFormat) code(text string) string {
+	return "`" + text + "`"
+}
+
+func (f markdownDocFormat) item(term string, desc []string, sb *strings.Builder) {
+	sb.WriteString("* ")
+	sb.WriteString(f.code(term))
+	sb.WriteByte(chars.CharLF)
+
+	for _, line := range desc {
+		sb.WriteString("\n  ")
+		sb.WriteString(strings.ReplaceAll(line, "\n", "\n  "))
+		sb.WriteByte(chars.CharLF)
+	}
+
+	sb.WriteByte(chars.CharLF)
+}
+
+func (markdownDocFormat) link(text, target, desc string, sb *strings.Builder) {
+	sb.WriteString("* [")
+	sb.WriteString(text)
+	sb.WriteString("](")
+	sb.WriteString(target)
+	sb.WriteByte(')')
+
+	if len(desc) > 0 {
+		sb.WriteString(" - ")
+		sb.WriteString(desc)
+	}
+
+	sb.WriteString("\n\n")
+}
+
+type asciiDocFormat struct{}
+
+func (asciiDocFormat) extension() string {
+	return ".adoc"
+}
+
+func (asciiDocFormat) heading(level int, text string, sb *strings.Builder) {
+	sb.WriteString(strings.Repeat("=", level))
+	sb.WriteByte(chars.CharSpace)
+	sb.WriteString(text)
+	sb.WriteString("\n\n")
+}
+
+func (asciiDocFormat) paragraph(text string, sb *strings.Builder) {
+	sb.WriteString(strings.TrimSpace(text))
+	sb.WriteString("\n\n")
+}
+
+func (asciiDocFormat) codeBlock(text string, sb *strings.Builder) {
+	sb.WriteString("----\n")
+	sb.WriteString(text)
+	sb.WriteString("\n----\n\n")
+}
+
+func (asciiDocFormat) code(text string) string {
+	return "`+" + text + "+`"
+}
+
+func (f asciiDocFormat) item(term string, desc []string, sb *strings.Builder) {
+	sb.WriteString(f.code(term))
+	sb.WriteString("::")
+
+	if len(desc) == 0 {
+		sb.WriteString(" {empty}\n\n")
+		return
+	}
+
+	for i, line := range desc {
+		if i > 0 {
+			sb.WriteString("\n+")
+		}
+		sb.WriteByte(chars.CharLF)
+		sb.WriteString(line)
+	}
+
+	sb.WriteString("\n\n")
+}
+
+func (asciiDocFormat) link(text, target, desc string, sb *strings.Builder) {
+	sb.WriteString("* xref:")
+	sb.WriteString(target)
+	sb.WriteByte('[')
+	sb.WriteString(text)
+	sb.WriteByte(']')
+
+	if len(desc) > 0 {
+		sb.WriteString(" - ")
+		sb.WriteString(desc)
+	}
+
+	sb.WriteString("\n\n")
+}
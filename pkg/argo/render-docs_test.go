@@ -0,0 +1,198 @@
+package argo_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+const docsMarkdown001 = `# %[1]s
+
+A tree.
+
+## Usage
+
+'''
+%[1]s [options] <command>
+'''
+
+## Flags
+
+* '-v | --verbose'
+
+  Be loud.
+
+* '--config=<path>'
+
+  Config file.
+
+* '-h | --help'
+
+  Prints this help text.
+
+## Commands
+
+* [build](%[1]s-build.md) - Builds things.
+
+* [files](%[1]s-files.md) - File operations.
+
+`
+
+const docsMarkdown002 = `# %[1]s files remove
+
+Removes files.
+
+## Usage
+
+'''
+%[1]s files remove [options] <file>
+'''
+
+Aliases: 'rm'
+
+## Flags
+
+* '-o <dir> | --output=<dir>'
+
+  Environment variable: 'APP_DIR'
+
+* '-h | --help'
+
+  Prints this help text.
+
+## Inherited Flags
+
+* '-v | --verbose'
+
+  Be loud.
+
+* '--config=<path>'
+
+  Config file.
+
+## Arguments
+
+* '<file>'
+
+  File to remove.
+
+`
+
+const docsAsciiDoc001 = `= %[1]s
+
+A tree.
+
+== Usage
+
+----
+%[1]s [options] <command>
+----
+
+== Flags
+
+'+-v | --verbose+'::
+Be loud.
+
+'+--config=<path>+'::
+Config file.
+
+'+-h | --help+'::
+Prints this help text.
+
+== Commands
+
+* xref:%[1]s-build.adoc[build] - Builds things.
+
+* xref:%[1]s-files.adoc[files] - File operations.
+
+`
+
+const docsAsciiDoc002 = `= %[1]s files remove
+
+Removes files.
+
+== Usage
+
+----
+%[1]s files remove [options] <file>
+----
+
+Aliases: '+rm+'
+
+== Flags
+
+'+-o <dir> | --output=<dir>+'::
+Environment variable: '+APP_DIR+'
+
+'+-h | --help+'::
+Prints this help text.
+
+== Inherited Flags
+
+'+-v | --verbose+'::
+Be loud.
+
+'+--config=<path>+'::
+Config file.
+
+== Arguments
+
+'+<file>+'::
+File to remove.
+
+`
+
+func docsCheck(t *testing.T, pattern, path string) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	// Backticks can't appear in raw string literals, so the expected documents
+	// use single quotes in their place.
+	expected := fmt.Sprintf(strings.ReplaceAll(pattern, "'", "`"), commandName)
+
+	if string(raw) != expected {
+		t.Errorf("expected %s to be:\n%s\nbut got:\n%s", filepath.Base(path), expected, raw)
+	}
+}
+
+// One markdown document is written per node.
+func TestCommandTreeDocExporter_markdown(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := argo.CommandTreeDocExporter(argo.DocFormatMarkdown).ExportDocs(renderTestTree(), dir); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	for _, name := range []string{commandName + "-build.md", commandName + "-files.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error("expected document", name, "to exist but got", err)
+		}
+	}
+
+	docsCheck(t, docsMarkdown001, filepath.Join(dir, commandName+".md"))
+	docsCheck(t, docsMarkdown002, filepath.Join(dir, commandName+"-files-remove.md"))
+}
+
+// One AsciiDoc document is written per node.
+func TestCommandTreeDocExporter_asciiDoc(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := argo.CommandTreeDocExporter(argo.DocFormatAsciiDoc).ExportDocs(renderTestTree(), dir); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	for _, name := range []string{commandName + "-build.adoc", commandName + "-files.adoc"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error("expected document", name, "to exist but got", err)
+		}
+	}
+
+	docsCheck(t, docsAsciiDoc001, filepath.Join(dir, commandName+".adoc"))
+	docsCheck(t, docsAsciiDoc002, filepath.Join(dir, commandName+"-files-remove.adoc"))
+}
@@ -0,0 +1,297 @@
+package argo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+)
+
+// JSONSchemaVersion is the version of the JSON document format written by the
+// JSON schema renderers.
+//
+// This value will be incremented whenever a change is made to the document
+// format that is not backwards compatible.
+const JSONSchemaVersion = 1
+
+const (
+	jsonKindCommand = "command"
+	jsonKindTree    = "tree"
+	jsonKindBranch  = "branch"
+	jsonKindLeaf    = "leaf"
+)
+
+const (
+	jsonConstraintMutuallyExclusive = "mutuallyExclusive"
+	jsonConstraintExactlyOne        = "exactlyOne"
+)
+
+// CommandJSONRenderer returns a SchemaRenderer instance that renders a JSON
+// description of the structure of Command instances.
+func CommandJSONRenderer() SchemaRenderer[Command] {
+	return comJSONRenderer{}
+}
+
+// CommandTreeJSONRenderer returns a SchemaRenderer instance that renders a JSON
+// description of the structure of CommandTree instances.
+func CommandTreeJSONRenderer() SchemaRenderer[CommandTree] {
+	return comTreeJSONRenderer{}
+}
+
+type comJSONRenderer struct{}
+
+func (r comJSONRenderer) RenderSchema(com Command, writer io.Writer) error {
+	return writeJSONSchema(newCommandJSONNode(jsonKindCommand, com), writer)
+}
+
+type comTreeJSONRenderer struct{}
+
+func (r comTreeJSONRenderer) RenderSchema(tree CommandTree, writer io.Writer) error {
+	return writeJSONSchema(newCommandNodeJSONNode(tree), writer)
+}
+
+func writeJSONSchema(root jsonCommandNode, writer io.Writer) error {
+	doc := jsonSchemaDocument{Version: JSONSchemaVersion, Command: root}
+
+	raw, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(append(raw, chars.CharLF))
+	return err
+}
+
+type jsonSchemaDocument struct {
+	Version int             `json:"schemaVersion"`
+	Command jsonCommandNode `json:"command"`
+}
+
+type jsonCommandNode struct {
+	Kind          string             `json:"kind"`
+	Name          string             `json:"name"`
+	Aliases       []string           `json:"aliases,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	FlagGroups    []jsonFlagGroup    `json:"flagGroups"`
+	Arguments     []jsonArgument     `json:"arguments,omitempty"`
+	UnmappedLabel string             `json:"unmappedLabel,omitempty"`
+	CommandGroups []jsonCommandGroup `json:"commandGroups,omitempty"`
+}
+
+type jsonFlagGroup struct {
+	Name        string     `json:"name,omitempty"`
+	Default     bool       `json:"default"`
+	Description string     `json:"description,omitempty"`
+	Constraint  string     `json:"constraint,omitempty"`
+	Flags       []jsonFlag `json:"flags"`
+}
+
+type jsonFlag struct {
+	Short       string         `json:"short,omitempty"`
+	Long        string         `json:"long,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required"`
+	Negatable   bool           `json:"negatable,omitempty"`
+	Counter     bool           `json:"counter,omitempty"`
+	Requires    []string       `json:"requires,omitempty"`
+	Conflicts   []string       `json:"conflictsWith,omitempty"`
+	Argument    *jsonArgument  `json:"argument,omitempty"`
+	Arguments   []jsonArgument `json:"arguments,omitempty"`
+}
+
+type jsonArgument struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	BindingType string `json:"bindingType,omitempty"`
+	HasDefault  bool   `json:"hasDefault"`
+	Default     any    `json:"default,omitempty"`
+	DefaultType string `json:"defaultType,omitempty"`
+	EnvVar      string `json:"envVar,omitempty"`
+	Variadic    bool   `json:"variadic,omitempty"`
+	MinCount    int    `json:"minCount,omitempty"`
+	MaxCount    int    `json:"maxCount,omitempty"`
+}
+
+type jsonCommandGroup struct {
+	Name        string            `json:"name,omitempty"`
+	Default     bool              `json:"default"`
+	Description string            `json:"description,omitempty"`
+	Commands    []jsonCommandNode `json:"commands"`
+}
+
+func newCommandJSONNode(kind string, com Command) jsonCommandNode {
+	out := jsonCommandNode{
+		Kind:          kind,
+		Name:          com.Name(),
+		Description:   com.Description(),
+		FlagGroups:    newJSONFlagGroups(visibleFlagGroups(com.FlagGroups())),
+		UnmappedLabel: com.GetUnmappedLabel(),
+	}
+
+	for _, arg := range visibleArguments(com.Arguments()) {
+		out.Arguments = append(out.Arguments, newJSONArgument(arg))
+	}
+
+	return out
+}
+
+func newCommandNodeJSONNode(node CommandNode) jsonCommandNode {
+	var out jsonCommandNode
+
+	switch n := node.(type) {
+	case CommandTree:
+		out = jsonCommandNode{
+			Kind:        jsonKindTree,
+			Name:        n.Name(),
+			Description: n.Description(),
+			FlagGroups:  newJSONFlagGroups(visibleFlagGroups(n.FlagGroups())),
+		}
+	case CommandBranch:
+		out = jsonCommandNode{
+			Kind:        jsonKindBranch,
+			Name:        n.Name(),
+			Description: n.Description(),
+			FlagGroups:  newJSONFlagGroups(visibleFlagGroups(n.FlagGroups())),
+		}
+	case CommandLeaf:
+		out = newCommandJSONNode(jsonKindLeaf, n)
+	default:
+		panic("illegal state: unrecognized command node type")
+	}
+
+	if child, ok := node.(CommandChild); ok {
+		out.Aliases = child.Aliases()
+	}
+
+	if parent, ok := node.(CommandParent); ok {
+		for _, group := range visibleCommandGroups(parent.CommandGroups()) {
+			jGroup := jsonCommandGroup{
+				Description: group.Description(),
+				Commands:    make([]jsonCommandNode, 0, len(group.Branches())+len(group.Leaves())),
+			}
+
+			if group.Name() == chars.DefaultGroupName {
+				jGroup.Default = true
+			} else {
+				jGroup.Name = group.Name()
+			}
+
+			for _, child := range sortedCommandGroupChildren(group) {
+				jGroup.Commands = append(jGroup.Commands, newCommandNodeJSONNode(child))
+			}
+
+			out.CommandGroups = append(out.CommandGroups, jGroup)
+		}
+	}
+
+	return out
+}
+
+func newJSONFlagGroups(groups []FlagGroup) []jsonFlagGroup {
+	out := make([]jsonFlagGroup, 0, len(groups))
+
+	for _, group := range groups {
+		jGroup := jsonFlagGroup{
+			Description: group.Description(),
+			Flags:       make([]jsonFlag, 0, len(group.Flags())),
+		}
+
+		if group.Name() == chars.DefaultGroupName {
+			jGroup.Default = true
+		} else {
+			jGroup.Name = group.Name()
+		}
+
+		if group.RequiresExactlyOne() {
+			jGroup.Constraint = jsonConstraintExactlyOne
+		} else if group.IsMutuallyExclusive() {
+			jGroup.Constraint = jsonConstraintMutuallyExclusive
+		}
+
+		for _, flag := range group.Flags() {
+			jFlag := jsonFlag{
+				Long:        flag.LongForm(),
+				Description: flag.Description(),
+				Required:    flag.IsRequired(),
+				Negatable:   flag.IsNegatable(),
+				Counter:     flag.IsCounter(),
+				Requires:    flag.RequiredFlags(),
+				Conflicts:   flag.ConflictingFlags(),
+			}
+
+			if flag.HasShortForm() {
+				jFlag.Short = string(flag.ShortForm())
+			}
+
+			if flag.HasArgument() {
+				arg := newJSONArgument(flag.Argument())
+				jFlag.Argument = &arg
+			}
+
+			if len(flag.Arguments()) > 1 {
+				for _, arg := range flag.Arguments() {
+					jFlag.Arguments = append(jFlag.Arguments, newJSONArgument(arg))
+				}
+			}
+
+			jGroup.Flags = append(jGroup.Flags, jFlag)
+		}
+
+		out = append(out, jGroup)
+	}
+
+	return out
+}
+
+func newJSONArgument(arg Argument) jsonArgument {
+	out := jsonArgument{
+		Name:        arg.Name(),
+		Description: arg.Description(),
+		Required:    arg.IsRequired(),
+		HasDefault:  arg.HasDefault(),
+		EnvVar:      arg.EnvVar(),
+		Variadic:    arg.IsVariadic(),
+		MinCount:    arg.MinCount(),
+		MaxCount:    arg.MaxCount(),
+	}
+
+	if arg.HasBinding() {
+		out.BindingType = arg.BindingType().String()
+	}
+
+	if arg.HasDefault() {
+		out.DefaultType = arg.DefaultType().String()
+		out.Default = jsonDefaultValue(arg.Default())
+	}
+
+	return out
+}
+
+// jsonDefaultValue returns the given default value in a form that may be
+// serialized to JSON.
+//
+// Default value providers are not called, and are instead omitted.  Values that
+// implement fmt.Stringer, or that cannot be serialized to JSON, are converted
+// to strings.
+func jsonDefaultValue(def any) any {
+	if def == nil {
+		return nil
+	}
+
+	if reflect.TypeOf(def).Kind() == reflect.Func {
+		return nil
+	}
+
+	if str, ok := def.(fmt.Stringer); ok {
+		return str.String()
+	}
+
+	if _, err := json.Marshal(def); err != nil {
+		return fmt.Sprint(def)
+	}
+
+	return def
+}
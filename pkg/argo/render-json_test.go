@@ -0,0 +1,157 @@
+package argo_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+const jsonOutput001 = `{
+  "schemaVersion": 1,
+  "command": {
+    "kind": "command",
+    "name": "%s",
+    "description": "Does things.",
+    "flagGroups": [
+      {
+        "default": true,
+        "flags": [
+          {
+            "short": "t",
+            "long": "timeout",
+            "description": "How long to wait.",
+            "required": true,
+            "argument": {
+              "name": "duration",
+              "required": true,
+              "bindingType": "time.Duration",
+              "hasDefault": true,
+              "default": "30s",
+              "defaultType": "time.Duration",
+              "envVar": "APP_TIMEOUT"
+            }
+          },
+          {
+            "short": "h",
+            "long": "help",
+            "description": "Prints this help text.",
+            "required": false
+          }
+        ]
+      }
+    ],
+    "arguments": [
+      {
+        "name": "count",
+        "required": false,
+        "bindingType": "int",
+        "hasDefault": true,
+        "defaultType": "func() int"
+      }
+    ]
+  }
+}
+`
+
+func TestCommandJSONRenderer01(t *testing.T) {
+	var timeout time.Duration
+	var count int
+
+	com := argo.NewCommandBuilder().
+		WithDescription("Does things.").
+		WithFlag(argo.NewFlagBuilder().
+			WithShortForm('t').
+			WithLongForm("timeout").
+			WithDescription("How long to wait.").
+			WithArgument(argo.NewArgumentBuilder().
+				WithName("duration").
+				WithBinding(&timeout).
+				WithDefault(30 * time.Second).
+				WithEnvVar("APP_TIMEOUT").
+				Require()).
+			Require()).
+		WithArgument(argo.NewArgumentBuilder().
+			WithName("count").
+			WithBinding(&count).
+			WithDefault(func() int { return 3 })).
+		MustParse([]string{"command", "-t", "5s"})
+
+	sb := new(strings.Builder)
+
+	if err := argo.CommandJSONRenderer().RenderSchema(com, sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	expected := strings.Replace(jsonOutput001, "%s", commandName, 1)
+
+	if sb.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, sb.String())
+	}
+}
+
+// Command trees include nested command groups, aliases, and node kinds.
+func TestCommandTreeJSONRenderer01(t *testing.T) {
+	tree := argo.NewCommandTreeBuilder().
+		WithBranch(argo.NewCommandBranchBuilder("files").
+			WithLeaf(argo.NewCommandLeafBuilder("remove").WithAliases("rm"))).
+		WithCommandGroup(argo.NewCommandGroupBuilder("Other").
+			WithLeaf(argo.NewCommandLeafBuilder("build"))).
+		MustParse([]string{"command", "build"})
+
+	sb := new(strings.Builder)
+
+	if err := argo.CommandTreeJSONRenderer().RenderSchema(tree, sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	var doc struct {
+		Command struct {
+			Kind          string
+			CommandGroups []struct {
+				Name     string
+				Default  bool
+				Commands []struct {
+					Kind          string
+					Name          string
+					CommandGroups []struct {
+						Commands []struct {
+							Kind    string
+							Name    string
+							Aliases []string
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if err := json.Unmarshal([]byte(sb.String()), &doc); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if doc.Command.Kind != "tree" {
+		t.Error("expected kind to be tree but was", doc.Command.Kind)
+	}
+
+	groups := doc.Command.CommandGroups
+	if len(groups) != 2 {
+		t.Fatal("expected 2 command groups but got", len(groups))
+	}
+
+	if !groups[0].Default || groups[1].Name != "Other" {
+		t.Error("expected default group followed by Other group but got", groups)
+	}
+
+	files := groups[0].Commands[0]
+	if files.Kind != "branch" || files.Name != "files" {
+		t.Error("expected files branch but got", files.Kind, files.Name)
+	}
+
+	remove := files.CommandGroups[0].Commands[0]
+	if remove.Kind != "leaf" || remove.Name != "remove" || len(remove.Aliases) != 1 || remove.Aliases[0] != "rm" {
+		t.Error("expected remove leaf with alias rm but got", remove)
+	}
+}
@@ -0,0 +1,564 @@
+package argo
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+	"github.com/Foxcapades/Argonaut/internal/util"
+)
+
+const (
+	manSection     = "1"
+	manOptionsName = "OPTIONS"
+	manOptsText    = `[\fIoptions\fR]`
+	manCommandText = `\fIcommand\fR`
+)
+
+// CommandManPageRenderer returns a HelpRenderer instance that renders roff
+// man(7) formatted manual pages for Command instances.
+func CommandManPageRenderer() HelpRenderer[Command] {
+	return comManRenderer{}
+}
+
+// CommandTreeManPageRenderer returns a ManPageRenderer instance that renders
+// roff man(7) formatted manual pages for CommandTree instances.
+func CommandTreeManPageRenderer() ManPageRenderer {
+	return comTreeManRenderer{}
+}
+
+type comManRenderer struct{}
+
+func (r comManRenderer) RenderHelp(com Command, writer io.Writer) error {
+	sb := new(strings.Builder)
+
+	renderManHeader(com.Name(), sb)
+	renderManName(com.Name(), com.Description(), sb)
+
+	sb.WriteString(".SH SYNOPSIS\n")
+	renderManCommandUsage(com.Name(), com, sb)
+	sb.WriteByte(chars.CharLF)
+
+	renderManDescription(com.Description(), sb)
+	renderManOptions(visibleFlagGroups(com.FlagGroups()), nil, sb)
+	renderManArguments(visibleArguments(com.Arguments()), sb)
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+type comTreeManRenderer struct{}
+
+func (r comTreeManRenderer) RenderHelp(tree CommandTree, writer io.Writer) error {
+	sb := new(strings.Builder)
+
+	renderManHeader(tree.Name(), sb)
+	renderManName(tree.Name(), tree.Description(), sb)
+
+	sb.WriteString(".SH SYNOPSIS\n")
+	renderManEmphasis(tree.Name(), sb)
+	if hasVisibleFlagGroups(tree.FlagGroups()) {
+		sb.WriteByte(chars.CharSpace)
+		sb.WriteString(manOptsText)
+	}
+	sb.WriteByte(chars.CharSpace)
+	sb.WriteString(manCommandText)
+	sb.WriteByte(chars.CharLF)
+
+	renderManDescription(tree.Description(), sb)
+	renderManOptions(visibleFlagGroups(tree.FlagGroups()), nil, sb)
+
+	sb.WriteString(".SH COMMANDS\n")
+
+	groups := visibleCommandGroups(tree.CommandGroups())
+	multiple := len(groups) > 1
+	for _, group := range groups {
+		if multiple || group.Name() != chars.DefaultGroupName {
+			sb.WriteString(".SS ")
+			sb.WriteString(manQuote(util.IfElse(group.Name() == chars.DefaultGroupName, defaultComGroupName, group.Name())))
+			sb.WriteByte(chars.CharLF)
+
+			if group.HasDescription() {
+				renderManParagraphs(group.Description(), sb)
+			}
+		}
+
+		for _, child := range sortedCommandGroupChildren(group) {
+			renderManCommandEntry(child, sb)
+		}
+	}
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+func (r comTreeManRenderer) RenderLeafPages(tree CommandTree, dir string) error {
+	var err error
+
+	walkCommandLeaves(tree, func(leaf CommandLeaf) {
+		if err != nil {
+			return
+		}
+
+		path := commandPath(leaf)
+		sb := new(strings.Builder)
+
+		renderManLeafPage(leaf, path, sb)
+
+		err = os.WriteFile(filepath.Join(dir, strings.Join(path, "-")+"."+manSection), []byte(sb.String()), 0644)
+	})
+
+	return err
+}
+
+func renderManLeafPage(leaf CommandLeaf, path []string, sb *strings.Builder) {
+	pageName := strings.Join(path, "-")
+
+	renderManHeader(pageName, sb)
+	renderManName(pageName, leaf.Description(), sb)
+
+	sb.WriteString(".SH SYNOPSIS\n")
+	renderManCommandUsage(strings.Join(path, " "), leaf, sb)
+	sb.WriteByte(chars.CharLF)
+
+	if leaf.HasAliases() {
+		aliases := slices.Clone(leaf.Aliases())
+		slices.Sort(aliases)
+
+		sb.WriteString(".PP\nAliases: ")
+		sb.WriteString(manEscape(strings.Join(aliases, ", ")))
+		sb.WriteByte(chars.CharLF)
+	}
+
+	renderManDescription(leaf.Description(), sb)
+	renderManOptions(visibleFlagGroups(leaf.FlagGroups()), flattenFlagInheritance(leaf), sb)
+	renderManArguments(visibleArguments(leaf.Arguments()), sb)
+
+	sb.WriteString(".SH SEE ALSO\n")
+	renderManEmphasis(path[0], sb)
+	sb.WriteString("(" + manSection + ")\n")
+}
+
+func renderManHeader(name string, sb *strings.Builder) {
+	sb.WriteString(".TH ")
+	sb.WriteString(manQuote(strings.ToUpper(name)))
+	sb.WriteString(" " + manSection + "\n")
+}
+
+func renderManName(name, desc string, sb *strings.Builder) {
+	sb.WriteString(".SH NAME\n")
+	sb.WriteString(manEscape(name))
+
+	if short := completionDescription(desc); len(short) > 0 {
+		sb.WriteString(` \- `)
+		sb.WriteString(manEscape(short))
+	}
+
+	sb.WriteByte(chars.CharLF)
+}
+
+func renderManDescription(desc string, sb *strings.Builder) {
+	if len(strings.TrimSpace(desc)) == 0 {
+		return
+	}
+
+	sb.WriteString(".SH DESCRIPTION\n")
+	renderManParagraphs(desc, sb)
+}
+
+// renderManCommandUsage renders the synopsis line for the given command using
+// the given command path as the command name.
+func renderManCommandUsage(path string, com Command, sb *strings.Builder) {
+	renderManEmphasis(path, sb)
+
+	hasOptionalFlags := false
+
+	for _, group := range visibleFlagGroups(com.FlagGroups()) {
+		for _, flag := range group.Flags() {
+			if flag.IsRequired() {
+				sb.WriteByte(chars.CharSpace)
+				renderManShortestFlag(flag, sb)
+			} else {
+				hasOptionalFlags = true
+			}
+		}
+	}
+
+	if hasOptionalFlags {
+		sb.WriteByte(chars.CharSpace)
+		sb.WriteString(manOptsText)
+	}
+
+	args := visibleArguments(com.Arguments())
+	multiArgs := len(args) > 1
+	for i, arg := range args {
+		if arg.HasBinding() && arg.BindingType().Kind() == reflect.Bool {
+			continue
+		}
+
+		sb.WriteByte(chars.CharSpace)
+		renderManArgumentName(arg, util.IfElse(multiArgs, i+1, 0), sb)
+	}
+
+	if com.HasUnmappedLabel() {
+		sb.WriteString(" [")
+		sb.WriteString(manEscape(com.GetUnmappedLabel()))
+		sb.WriteByte(argOptSuffix)
+	}
+}
+
+// renderManCommandEntry renders a COMMANDS section entry for the given child
+// node, followed by entries for all of its descendants.
+func renderManCommandEntry(child CommandChild, sb *strings.Builder) {
+	path := commandPath(child)
+	name := strings.Join(path[1:], " ")
+
+	sb.WriteString(".TP\n")
+
+	if leaf, ok := child.(CommandLeaf); ok {
+		renderManCommandUsage(name, leaf, sb)
+	} else {
+		renderManEmphasis(name, sb)
+		sb.WriteByte(chars.CharSpace)
+		sb.WriteString(manCommandText)
+	}
+	sb.WriteByte(chars.CharLF)
+
+	if child.HasDescription() {
+		renderManParagraphs(child.Description(), sb)
+	}
+
+	if child.HasAliases() {
+		aliases := slices.Clone(child.Aliases())
+		slices.Sort(aliases)
+
+		if child.HasDescription() {
+			sb.WriteString(".br\n")
+		}
+
+		sb.WriteString("Aliases: ")
+		sb.WriteString(manEscape(strings.Join(aliases, ", ")))
+		sb.WriteByte(chars.CharLF)
+	}
+
+	if hasVisibleFlagGroups(child.FlagGroups()) {
+		sb.WriteString(".RS\n")
+
+		groups := visibleFlagGroups(child.FlagGroups())
+		multiple := len(groups) > 1
+		for _, group := range groups {
+			if multiple || group.Name() != chars.DefaultGroupName {
+				sb.WriteString(".PP\n")
+				renderManEmphasis(flagGroupDisplayName(group, multiple), sb)
+				sb.WriteByte(chars.CharLF)
+			}
+
+			for _, flag := range group.Flags() {
+				renderManFlag(flag, true, true, sb)
+			}
+		}
+
+		sb.WriteString(".RE\n")
+	}
+
+	if parent, ok := child.(CommandParent); ok {
+		for _, group := range visibleCommandGroups(parent.CommandGroups()) {
+			for _, grandchild := range sortedCommandGroupChildren(group) {
+				renderManCommandEntry(grandchild, sb)
+			}
+		}
+	}
+}
+
+func renderManOptions(groups []FlagGroup, inherited []flagForms, sb *strings.Builder) {
+	if len(groups) == 0 && len(inherited) == 0 {
+		return
+	}
+
+	sb.WriteString(".SH " + manOptionsName + "\n")
+
+	multiple := len(groups) > 1 || len(inherited) > 0
+	for _, group := range groups {
+		if multiple || group.Name() != chars.DefaultGroupName {
+			sb.WriteString(".SS ")
+			sb.WriteString(manQuote(flagGroupDisplayName(group, multiple)))
+			sb.WriteByte(chars.CharLF)
+
+			if group.HasDescription() {
+				renderManParagraphs(group.Description(), sb)
+			}
+		}
+
+		if group.IsMutuallyExclusive() {
+			renderManParagraphs(flagGroupConstraintNote(group), sb)
+		}
+
+		for _, flag := range group.Flags() {
+			renderManFlag(flag, true, true, sb)
+		}
+	}
+
+	if len(inherited) > 0 {
+		sb.WriteString(".SS ")
+		sb.WriteString(manQuote(inheritedFlagsName))
+		sb.WriteByte(chars.CharLF)
+
+		for i := range inherited {
+			renderManFlag(inherited[i].flag, inherited[i].short, inherited[i].long, sb)
+		}
+	}
+}
+
+func renderManFlag(flag Flag, short, long bool, sb *strings.Builder) {
+	sb.WriteString(".TP\n")
+
+	showArg := flag.HasArgument() && flagArgShouldBeRendered(flag.Argument())
+
+	if short && flag.HasShortForm() {
+		sb.WriteString(`\fB\-`)
+		sb.WriteString(manEscape(string(flag.ShortForm())))
+		sb.WriteString(`\fR`)
+
+		if showArg {
+			sb.WriteByte(chars.CharSpace)
+			renderManFlagArgumentNames(flag, sb)
+		}
+
+		if long && flag.HasLongForm() {
+			sb.WriteString(", ")
+		}
+	}
+
+	if long && flag.HasLongForm() {
+		sb.WriteString(`\fB\-\-`)
+		if flag.IsNegatable() {
+			sb.WriteString(`[no\-]`)
+		}
+		sb.WriteString(manEscape(flag.LongForm()))
+		sb.WriteString(`\fR`)
+
+		if showArg {
+			sb.WriteByte(chars.CharEquals)
+			renderManFlagArgumentNames(flag, sb)
+		}
+	}
+
+	sb.WriteByte(chars.CharLF)
+
+	hasBody := false
+
+	if flag.HasDescription() {
+		renderManParagraphs(flag.Description(), sb)
+		hasBody = true
+	}
+
+	for i, arg := range flag.Arguments() {
+		if arg.HasDescription() {
+			if hasBody {
+				sb.WriteString(".br\n")
+			}
+			renderManArgumentName(arg, flagArgumentIndex(flag, i), sb)
+			sb.WriteString(": ")
+			sb.WriteString(manEscape(strings.Join(strings.Fields(arg.Description()), " ")))
+			sb.WriteByte(chars.CharLF)
+			hasBody = true
+		}
+
+		if arg.HasEnvVar() {
+			if hasBody {
+				sb.WriteString(".br\n")
+			}
+			sb.WriteString("Environment variable: ")
+			renderManEmphasis(arg.EnvVar(), sb)
+			sb.WriteByte(chars.CharLF)
+			hasBody = true
+		}
+	}
+
+	for _, note := range flagConstraintNotes(flag) {
+		if hasBody {
+			sb.WriteString(".br\n")
+		}
+		sb.WriteString(manEscape(note))
+		sb.WriteByte(chars.CharLF)
+		hasBody = true
+	}
+}
+
+func renderManShortestFlag(flag Flag, sb *strings.Builder) {
+	if flag.HasShortForm() {
+		sb.WriteString(`\fB\-`)
+		sb.WriteString(manEscape(string(flag.ShortForm())))
+		sb.WriteString(`\fR`)
+
+		if flag.HasArgument() {
+			sb.WriteByte(chars.CharSpace)
+			renderManFlagArgumentNames(flag, sb)
+		}
+	} else {
+		sb.WriteString(`\fB\-\-`)
+		if flag.IsNegatable() {
+			sb.WriteString(`[no\-]`)
+		}
+		sb.WriteString(manEscape(flag.LongForm()))
+		sb.WriteString(`\fR`)
+
+		if flag.HasArgument() {
+			sb.WriteByte(chars.CharEquals)
+			renderManFlagArgumentNames(flag, sb)
+		}
+	}
+}
+
+func renderManArguments(args []Argument, sb *strings.Builder) {
+	writeArgs := false
+	for _, arg := range args {
+		if arg.HasDescription() || arg.HasEnvVar() {
+			writeArgs = true
+		}
+	}
+
+	if !writeArgs {
+		return
+	}
+
+	sb.WriteString(".SH ARGUMENTS\n")
+
+	multiArgs := len(args) > 1
+	for i, arg := range args {
+		sb.WriteString(".TP\n")
+		renderManArgumentName(arg, util.IfElse(multiArgs, i+1, 0), sb)
+		sb.WriteByte(chars.CharLF)
+
+		if arg.HasDescription() {
+			renderManParagraphs(arg.Description(), sb)
+		}
+
+		if arg.HasEnvVar() {
+			if arg.HasDescription() {
+				sb.WriteString(".br\n")
+			}
+			sb.WriteString("Environment variable: ")
+			renderManEmphasis(arg.EnvVar(), sb)
+			sb.WriteByte(chars.CharLF)
+		}
+	}
+}
+
+// renderManFlagArgumentNames writes the names of all the arguments of the given
+// flag, separated by spaces.
+func renderManFlagArgumentNames(flag Flag, sb *strings.Builder) {
+	for i, arg := range flag.Arguments() {
+		if i > 0 {
+			sb.WriteByte(chars.CharSpace)
+		}
+
+		renderManArgumentName(arg, flagArgumentIndex(flag, i), sb)
+	}
+}
+
+func renderManArgumentName(arg Argument, argIndex int, sb *strings.Builder) {
+	if !arg.IsRequired() {
+		sb.WriteByte(argOptPrefix)
+	}
+
+	sb.WriteString(`\fI`)
+	sb.WriteString(manEscape(renderArgName(arg, argIndex)))
+	sb.WriteString(`\fR`)
+
+	if !arg.IsRequired() {
+		sb.WriteByte(argOptSuffix)
+	}
+}
+
+func renderManEmphasis(text string, sb *strings.Builder) {
+	sb.WriteString(`\fB`)
+	sb.WriteString(manEscape(text))
+	sb.WriteString(`\fR`)
+}
+
+// renderManParagraphs writes the given text as one or more roff paragraphs,
+// treating blank lines as paragraph breaks.
+func renderManParagraphs(text string, sb *strings.Builder) {
+	for i, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		paragraph = strings.Join(strings.Fields(paragraph), " ")
+
+		if len(paragraph) == 0 {
+			continue
+		}
+
+		if i > 0 {
+			sb.WriteString(".PP\n")
+		}
+
+		paragraph = manEscape(paragraph)
+
+		// Lines starting with a control character would be interpreted as roff
+		// requests.
+		if paragraph[0] == '.' || paragraph[0] == '\'' {
+			sb.WriteString(`\&`)
+		}
+
+		sb.WriteString(paragraph)
+		sb.WriteByte(chars.CharLF)
+	}
+}
+
+// manEscape escapes the given text for safe inclusion in roff output.
+func manEscape(text string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+}
+
+func manQuote(text string) string {
+	return `"` + strings.ReplaceAll(manEscape(text), `"`, `\(dq`) + `"`
+}
+
+// commandPath returns the names of the given node and all of its parents,
+// starting with the root of the tree.
+func commandPath(node CommandNode) []string {
+	path := make([]string, 0, 4)
+
+	for current := node; current != nil; current = current.Parent() {
+		path = append(path, current.Name())
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
+
+// sortedCommandGroupChildren returns the branches and leaves of the given group
+// sorted by name.
+func sortedCommandGroupChildren(group CommandGroup) []CommandChild {
+	out := make([]CommandChild, 0, len(group.Branches())+len(group.Leaves()))
+
+	for _, branch := range group.Branches() {
+		out = append(out, branch)
+	}
+
+	for _, leaf := range group.Leaves() {
+		out = append(out, leaf)
+	}
+
+	slices.SortFunc(out, func(a, b CommandChild) int { return strings.Compare(a.Name(), b.Name()) })
+
+	return out
+}
+
+// walkCommandLeaves calls the given function for every leaf under the given
+// parent node, in name order.
+func walkCommandLeaves(parent CommandParent, fn func(leaf CommandLeaf)) {
+	for _, group := range visibleCommandGroups(parent.CommandGroups()) {
+		for _, child := range sortedCommandGroupChildren(group) {
+			if leaf, ok := child.(CommandLeaf); ok {
+				fn(leaf)
+			} else if branch, ok := child.(CommandParent); ok {
+				walkCommandLeaves(branch, fn)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package argo_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+const manOutput001 = `.TH "%[2]s" 1
+.SH NAME
+%[1]s \- Does things.
+.SH SYNOPSIS
+\fB%[1]s\fR \fB\-\-name\fR=\fIname\fR [\fIoptions\fR] \fIfile\fR
+.SH DESCRIPTION
+Does things.
+.PP
+Second paragraph.
+.SH OPTIONS
+.SS "General Flags"
+.TP
+\fB\-\-name\fR=\fIname\fR
+The name.
+.SS "Output"
+.TP
+\fB\-q\fR, \fB\-\-quiet\fR
+Be quiet.
+.SS "Help Flags"
+.TP
+\fB\-h\fR, \fB\-\-help\fR
+Prints this help text.
+.SH ARGUMENTS
+.TP
+\fIfile\fR
+Input file.
+.br
+Environment variable: \fBAPP_FILE\fR
+`
+
+func TestCommandManPageRenderer01(t *testing.T) {
+	com := argo.NewCommandBuilder().
+		WithDescription("Does things.\n\nSecond paragraph.").
+		WithFlag(argo.NewFlagBuilder().
+			WithLongForm("name").
+			WithDescription("The name.").
+			WithArgument(argo.NewArgumentBuilder().WithName("name").Require()).
+			Require()).
+		WithFlagGroup(argo.NewFlagGroupBuilder("Output").
+			WithFlag(argo.NewFlagBuilder().WithShortForm('q').WithLongForm("quiet").WithDescription("Be quiet."))).
+		WithArgument(argo.NewArgumentBuilder().
+			WithName("file").
+			WithDescription("Input file.").
+			WithEnvVar("APP_FILE").
+			Require()).
+		MustParse([]string{"command", "--name", "foo", "bar"})
+
+	sb := new(strings.Builder)
+
+	if err := argo.CommandManPageRenderer().RenderHelp(com, sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	name := strings.ReplaceAll(commandName, "-", `\-`)
+	expected := strings.ReplaceAll(strings.ReplaceAll(manOutput001, "%[1]s", name), "%[2]s", strings.ToUpper(name))
+
+	if sb.String() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, sb.String())
+	}
+}
+
+// Tree pages contain a COMMANDS section listing every node.
+func TestCommandTreeManPageRenderer01(t *testing.T) {
+	sb := new(strings.Builder)
+
+	if err := argo.CommandTreeManPageRenderer().RenderHelp(renderTestTree(), sb); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	out := sb.String()
+
+	for _, exp := range []string{
+		".SH NAME\n",
+		".SH SYNOPSIS\n",
+		".SH DESCRIPTION\nA tree.\n",
+		".SH OPTIONS\n.TP\n\\fB\\-v\\fR, \\fB\\-\\-verbose\\fR\nBe loud.\n",
+		".SH COMMANDS\n.TP\n\\fBbuild\\fR [\\fIoptions\\fR]\n",
+		".TP\n\\fBfiles remove\\fR [\\fIoptions\\fR] \\fIfile\\fR\nRemoves files.\n.br\nAliases: rm\n",
+	} {
+		if !strings.Contains(out, exp) {
+			t.Errorf("expected man page to contain %q but it did not:\n%s", exp, out)
+		}
+	}
+}
+
+// One page is written per leaf.
+func TestCommandTreeManPageRenderer02(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := argo.CommandTreeManPageRenderer().RenderLeafPages(renderTestTree(), dir); err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	for _, name := range []string{commandName + "-build.1", commandName + "-files-remove.1"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error("expected page", name, "to exist but got", err)
+		}
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, commandName+"-files-remove.1"))
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if !strings.Contains(string(raw), ".SS \"Inherited Flags\"\n.TP\n\\fB\\-v\\fR, \\fB\\-\\-verbose\\fR\n") {
+		t.Error("expected leaf page to contain inherited flags but got:\n" + string(raw))
+	}
+}
@@ -15,3 +15,37 @@ type HelpRenderer[T any] interface {
 	// given io.Writer instance.
 	RenderHelp(command T, writer io.Writer) error
 }
+
+// A CompletionRenderer is a type that renders a shell completion script for
+// the given type to the given io.Writer instance.
+//
+// This interface may be implemented to provide custom completion scripts for
+// your command.
+type CompletionRenderer[T any] interface {
+
+	// RenderCompletion renders a completion script for the given command and
+	// writes it to the given io.Writer instance.
+	RenderCompletion(command T, writer io.Writer) error
+}
+
+// A ManPageRenderer is a HelpRenderer that renders roff man(7) formatted
+// manual pages for a CommandTree.
+type ManPageRenderer interface {
+	HelpRenderer[CommandTree]
+
+	// RenderLeafPages renders a separate manual page for every leaf in the given
+	// command tree and writes them to the given directory.
+	//
+	// Each page is named for the full path to its leaf joined with dashes, for
+	// example `app-branch-leaf.1`.
+	RenderLeafPages(tree CommandTree, dir string) error
+}
+
+// A SchemaRenderer is a type that renders a machine-readable description of
+// the structure of the given type to the given io.Writer instance.
+type SchemaRenderer[T any] interface {
+
+	// RenderSchema renders a description of the structure of the given command
+	// and writes it to the given io.Writer instance.
+	RenderSchema(command T, writer io.Writer) error
+}
@@ -0,0 +1,215 @@
+package argo
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+)
+
+const (
+	// responseFilePrefix is the prefix that marks a CLI argument as a reference
+	// to a response file.
+	responseFilePrefix = '@'
+
+	// responseFileStdin is the response file path that refers to stdin.
+	responseFileStdin = "-"
+)
+
+// expandResponseFiles replaces every `@path` argument in the given CLI
+// arguments with the arguments read from the response file at that path.
+//
+// The first argument is the program name and is never expanded.  Arguments
+// following an end-of-arguments boundary, "--", are not expanded.
+func expandResponseFiles(args []string, rt *Runtime) ([]string, error) {
+	if len(args) < 2 {
+		return args, nil
+	}
+
+	expander := responseFileExpander{runtime: rt}
+
+	out, err := expander.expand(args[1:], "")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{args[0]}, out...), nil
+}
+
+// responseFileExpander expands response file references while tracking the
+// chain of response files currently being read to detect cycles.
+type responseFileExpander struct {
+	runtime  *Runtime
+	stack    []string
+	boundary bool
+}
+
+// expand expands the response file references in the given arguments.
+//
+// Relative response file paths are resolved against the given directory, or
+// the working directory if the given directory is blank.
+func (e *responseFileExpander) expand(args []string, dir string) ([]string, error) {
+	out := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if e.boundary || len(arg) < 2 || arg[0] != responseFilePrefix {
+			if arg == chars.StrDoubleDash {
+				e.boundary = true
+			}
+
+			out = append(out, arg)
+			continue
+		}
+
+		tokens, err := e.read(arg[1:], dir)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, tokens...)
+	}
+
+	return out, nil
+}
+
+// read reads and expands the response file at the given path.
+func (e *responseFileExpander) read(path, dir string) ([]string, error) {
+	var raw []byte
+	var err error
+
+	key := path
+
+	if path == responseFileStdin {
+		dir = ""
+	} else {
+		if !filepath.IsAbs(path) && len(dir) > 0 {
+			path = filepath.Join(dir, path)
+		}
+
+		if key, err = filepath.Abs(path); err != nil {
+			return nil, newResponseFileError(path, err)
+		}
+
+		dir = filepath.Dir(key)
+	}
+
+	if slices.Contains(e.stack, key) {
+		return nil, newResponseFileError(path, errors.New("response file cycle: "+strings.Join(append(e.stack, key), " -> ")))
+	}
+
+	if path == responseFileStdin {
+		raw, err = io.ReadAll(e.runtime.stdin())
+	} else {
+		raw, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, newResponseFileError(path, err)
+	}
+
+	tokens, err := tokenizeResponseFile(string(raw))
+	if err != nil {
+		return nil, newResponseFileError(path, err)
+	}
+
+	e.stack = append(e.stack, key)
+	defer func() { e.stack = e.stack[:len(e.stack)-1] }()
+
+	return e.expand(tokens, dir)
+}
+
+// tokenizeResponseFile splits the contents of a response file into individual
+// CLI arguments.
+//
+// Arguments are separated by whitespace.  Whitespace may be included in an
+// argument by wrapping it in single or double quotes, or by escaping it with a
+// backslash.  Single quoted text is taken literally, while double quoted text
+// may contain backslash escaped double quotes and backslashes.  Lines whose
+// first non-whitespace character is '#' are comments.
+func tokenizeResponseFile(text string) ([]string, error) {
+	out := make([]string, 0, 16)
+	sb := new(strings.Builder)
+	inToken := false
+	lineStart := true
+
+	flush := func() {
+		if inToken {
+			out = append(out, sb.String())
+			sb.Reset()
+			inToken = false
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch c {
+		case chars.CharLF:
+			flush()
+			lineStart = true
+			continue
+
+		case chars.CharSpace, chars.CharTab, chars.CharCR:
+			flush()
+			continue
+
+		case '#':
+			if lineStart && !inToken {
+				for i+1 < len(text) && text[i+1] != chars.CharLF {
+					i++
+				}
+				continue
+			}
+
+			inToken = true
+			sb.WriteByte(c)
+
+		case '\'':
+			end := strings.IndexByte(text[i+1:], '\'')
+			if end < 0 {
+				return nil, errors.New("unterminated single quote")
+			}
+
+			inToken = true
+			sb.WriteString(text[i+1 : i+1+end])
+			i += end + 1
+
+		case '"':
+			inToken = true
+
+			for i++; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' && i+1 < len(text) && (text[i+1] == '"' || text[i+1] == '\\') {
+					i++
+				}
+
+				sb.WriteByte(text[i])
+			}
+
+			if i >= len(text) {
+				return nil, errors.New("unterminated double quote")
+			}
+
+		case '\\':
+			inToken = true
+
+			if i+1 < len(text) {
+				i++
+				sb.WriteByte(text[i])
+			}
+
+		default:
+			inToken = true
+			sb.WriteByte(c)
+		}
+
+		lineStart = false
+	}
+
+	flush()
+
+	return out, nil
+}
@@ -0,0 +1,164 @@
+package argo_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cli "github.com/Foxcapades/Argonaut"
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+func writeResponseFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Response file arguments are tokenized and expanded in place
+func TestResponseFile_expand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "args.rsp")
+	writeResponseFile(t, path, "# build arguments\n--name 'My App' \"a \\\"quoted\\\" value\"\n  # indented comment\nescaped\\ space\n")
+
+	var name string
+
+	com, err := cli.Command().
+		WithResponseFiles().
+		WithFlag(cli.LongFlag("name").WithBinding(&name, true)).
+		Parse([]string{"command", "first", "@" + path, "last"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if name != "My App" {
+		t.Error("expected name to be 'My App' but was", name)
+	}
+
+	expected := []string{"first", `a "quoted" value`, "escaped space", "last"}
+	actual := com.UnmappedInputs()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected unmapped inputs to be %q but was %q", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected unmapped inputs to be %q but was %q", expected, actual)
+		}
+	}
+}
+
+// Nested response files are resolved relative to the referencing file
+func TestResponseFile_nested(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeResponseFile(t, filepath.Join(dir, "outer.rsp"), "leaf @sub/inner.rsp")
+	writeResponseFile(t, filepath.Join(dir, "sub", "inner.rsp"), "--count=3")
+
+	var count int
+
+	tree, err := cli.Tree().
+		WithResponseFiles().
+		WithLeaf(cli.Leaf("leaf").
+			WithFlag(cli.LongFlag("count").WithBinding(&count, true))).
+		Parse([]string{"command", "@" + filepath.Join(dir, "outer.rsp")})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if tree.SelectedCommand().Name() != "leaf" {
+		t.Error("expected leaf to be selected but was", tree.SelectedCommand().Name())
+	}
+
+	if count != 3 {
+		t.Error("expected count to be 3 but was", count)
+	}
+}
+
+// Response file cycles are reported as errors
+func TestResponseFile_cycle(t *testing.T) {
+	dir := t.TempDir()
+	writeResponseFile(t, filepath.Join(dir, "a.rsp"), "@b.rsp")
+	writeResponseFile(t, filepath.Join(dir, "b.rsp"), "@a.rsp")
+
+	_, err := cli.Command().
+		WithResponseFiles().
+		Parse([]string{"command", "@" + filepath.Join(dir, "a.rsp")})
+
+	var rfe argo.ResponseFileError
+	if !errors.As(err, &rfe) {
+		t.Fatal("expected err to be a ResponseFileError but was", err)
+	}
+
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Error("expected err to describe a cycle but was", err)
+	}
+}
+
+// Missing response files are reported as errors
+func TestResponseFile_missing(t *testing.T) {
+	_, err := cli.Command().
+		WithResponseFiles().
+		Parse([]string{"command", "@" + filepath.Join(t.TempDir(), "missing.rsp")})
+
+	var rfe argo.ResponseFileError
+	if !errors.As(err, &rfe) || !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected err to be a ResponseFileError for a missing file but was", err)
+	}
+}
+
+// Response file arguments may be read from the runtime stdin
+func TestResponseFile_stdin(t *testing.T) {
+	com, err := cli.Command().
+		WithResponseFiles().
+		WithRuntime(&argo.Runtime{Stdin: strings.NewReader("from stdin")}).
+		Parse([]string{"command", "@-"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(com.UnmappedInputs()) != 2 || com.UnmappedInputs()[1] != "stdin" {
+		t.Error("expected stdin arguments to be expanded but was", com.UnmappedInputs())
+	}
+}
+
+// Response files are not expanded unless enabled, or after a boundary
+func TestResponseFile_literal(t *testing.T) {
+	com, err := cli.Command().
+		Parse([]string{"command", "@args.rsp"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(com.UnmappedInputs()) != 1 || com.UnmappedInputs()[0] != "@args.rsp" {
+		t.Error("expected @args.rsp to be taken literally but was", com.UnmappedInputs())
+	}
+
+	com, err = cli.Command().
+		WithResponseFiles().
+		Parse([]string{"command", "@", "--", "@args.rsp"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if len(com.UnmappedInputs()) != 1 || com.UnmappedInputs()[0] != "@" {
+		t.Error("expected lone @ to be taken literally but was", com.UnmappedInputs())
+	}
+
+	if len(com.PassthroughInputs()) != 1 || com.PassthroughInputs()[0] != "@args.rsp" {
+		t.Error("expected @args.rsp after boundary to be taken literally but was", com.PassthroughInputs())
+	}
+}
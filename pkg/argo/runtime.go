@@ -0,0 +1,191 @@
+package argo
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// A Runtime describes the process environment that commands are parsed in.
+//
+// The runtime is used for writing help text and completion candidates, for
+// reading response file arguments from stdin, for exiting after help text has
+// been printed, for determining the name of the program, and for looking up
+// environment variables.
+//
+// Errors and warnings are never written to the runtime's streams, they are
+// returned to the caller by Parse and Execute, and recorded on the parsed
+// command's warnings.
+//
+// Any field left unset will fall back to the equivalent value from the os
+// package, meaning a zero value Runtime behaves exactly like the default
+// process environment.
+//
+// Example:
+//     stdout := new(bytes.Buffer)
+//     cli.Command().
+//         WithRuntime(&argo.Runtime{
+//             Stdout:      stdout,
+//             Exit:        func(code int) {},
+//             ProgramName: "my-app",
+//         })
+type Runtime struct {
+	// Stdout is the writer that help text and completion candidates are written
+	// to.
+	//
+	// Defaults to os.Stdout.
+	Stdout io.Writer
+
+	// Stderr is the writer that help text for incomplete commands is written to.
+	//
+	// Defaults to os.Stderr.
+	Stderr io.Writer
+
+	// Stdin is the reader that response file arguments given as `@-` are read
+	// from.
+	//
+	// Defaults to os.Stdin.
+	Stdin io.Reader
+
+	// Exit is the function that will be called to exit the program after help
+	// text has been printed.
+	//
+	// If the given function returns rather than terminating the process, parsing
+	// will stop and an ExitError will be returned instead.
+	//
+	// Defaults to os.Exit.
+	Exit func(code int)
+
+	// ProgramName is the name of the program used when rendering help text.
+	//
+	// Defaults to the base name of os.Args[0].
+	ProgramName string
+
+	// LookupEnv is the function that will be used to look up the values of
+	// environment variables.
+	//
+	// Defaults to os.LookupEnv.
+	LookupEnv func(key string) (string, bool)
+}
+
+func (r *Runtime) stdout() io.Writer {
+	if r == nil || r.Stdout == nil {
+		return os.Stdout
+	}
+
+	return r.Stdout
+}
+
+func (r *Runtime) stderr() io.Writer {
+	if r == nil || r.Stderr == nil {
+		return os.Stderr
+	}
+
+	return r.Stderr
+}
+
+func (r *Runtime) stdin() io.Reader {
+	if r == nil || r.Stdin == nil {
+		return os.Stdin
+	}
+
+	return r.Stdin
+}
+
+func (r *Runtime) exit(code int) {
+	if r == nil || r.Exit == nil {
+		os.Exit(code)
+	} else {
+		r.Exit(code)
+	}
+}
+
+func (r *Runtime) programName() string {
+	if r == nil || len(r.ProgramName) == 0 {
+		return filepath.Base(os.Args[0])
+	}
+
+	return r.ProgramName
+}
+
+func (r *Runtime) lookupEnv(key string) (string, bool) {
+	if r == nil || r.LookupEnv == nil {
+		return os.LookupEnv(key)
+	}
+
+	return r.LookupEnv(key)
+}
+
+// posixlyCorrectEnvVar is the name of the environment variable that, when set,
+// disables interspersed flags and positional arguments for all commands, as
+// with GNU getopt.
+const posixlyCorrectEnvVar = "POSIXLY_CORRECT"
+
+func (r *Runtime) posixlyCorrect() bool {
+	_, ok := r.lookupEnv(posixlyCorrectEnvVar)
+	return ok
+}
+
+// nodeRuntime returns the Runtime of the CommandTree the given node belongs
+// to.
+func nodeRuntime(node CommandNode) *Runtime {
+	if tree := nodeTree(node); tree != nil {
+		return tree.getRuntime()
+	}
+
+	return nil
+}
+
+// nodeTree returns the CommandTree the given node belongs to, or nil if the
+// root of the given node is not a CommandTree.
+func nodeTree(node CommandNode) CommandTree {
+	for node.HasParent() {
+		node = node.Parent()
+	}
+
+	if tree, ok := node.(CommandTree); ok {
+		return tree
+	}
+
+	return nil
+}
+
+// nodeAllowsInterspersed indicates whether the CommandTree the given node
+// belongs to allows flags to follow positional arguments.
+func nodeAllowsInterspersed(node CommandNode) bool {
+	if tree := nodeTree(node); tree != nil {
+		return tree.allowsInterspersed()
+	}
+
+	return true
+}
+
+// nodeStrictParsing indicates whether the CommandTree the given node belongs
+// to has strict parsing enabled.
+func nodeStrictParsing(node CommandNode) bool {
+	if tree := nodeTree(node); tree != nil {
+		return tree.strictParsing()
+	}
+
+	return false
+}
+
+// nodeSuggestionThreshold returns the maximum edit distance used when
+// suggesting alternatives for unrecognized input to the given node.
+func nodeSuggestionThreshold(node CommandNode) int {
+	if tree := nodeTree(node); tree != nil {
+		return tree.suggestionThreshold()
+	}
+
+	return DefaultSuggestionThreshold
+}
+
+// nodeAllowsAbbreviations indicates whether the CommandTree the given node
+// belongs to allows abbreviated long flags and subcommand names.
+func nodeAllowsAbbreviations(node CommandNode) bool {
+	if tree := nodeTree(node); tree != nil {
+		return tree.allowsAbbreviations()
+	}
+
+	return false
+}
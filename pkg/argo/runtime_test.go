@@ -0,0 +1,154 @@
+package argo_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	cli "github.com/Foxcapades/Argonaut"
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+func newTestRuntime() (*argo.Runtime, *bytes.Buffer, *bytes.Buffer, *int) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	code := -1
+
+	return &argo.Runtime{
+		Stdout:      stdout,
+		Stderr:      stderr,
+		Exit:        func(c int) { code = c },
+		ProgramName: "my-app",
+		LookupEnv: func(key string) (string, bool) {
+			if key == "MY_APP_VALUE" {
+				return "from-env", true
+			}
+			return "", false
+		},
+	}, stdout, stderr, &code
+}
+
+// Command help flag writes to the runtime stdout and calls the runtime exit
+func TestRuntime_commandHelp(t *testing.T) {
+	rt, stdout, _, code := newTestRuntime()
+	called := false
+
+	_, err := cli.Command().
+		WithRuntime(rt).
+		WithCallback(func(argo.Command) { called = true }).
+		Parse([]string{"my-app", "--help"})
+
+	var ee argo.ExitError
+	if !errors.As(err, &ee) {
+		t.Fatal("expected err to be an ExitError but was", err)
+	}
+
+	if ee.Code() != 0 || *code != 0 {
+		t.Error("expected exit code to be 0 but was", *code)
+	}
+
+	if !strings.HasPrefix(stdout.String(), "Usage:\n  my-app [options]") {
+		t.Error("expected help text to be written to stdout but was", stdout.String())
+	}
+
+	if called {
+		t.Error("expected command callback not to be called but it was")
+	}
+}
+
+// Leaf help flag writes to the tree runtime stdout
+func TestRuntime_leafHelp(t *testing.T) {
+	rt, stdout, _, code := newTestRuntime()
+
+	_, err := cli.Tree().
+		WithRuntime(rt).
+		WithBranch(cli.Branch("branch").
+			WithLeaf(cli.Leaf("leaf"))).
+		Parse([]string{"my-app", "branch", "leaf", "-h"})
+
+	if err == nil {
+		t.Fatal("expected err not to be nil but it was")
+	}
+
+	if *code != 0 {
+		t.Error("expected exit code to be 0 but was", *code)
+	}
+
+	if !strings.HasPrefix(stdout.String(), "Usage:\n  my-app branch leaf") {
+		t.Error("expected leaf help text to be written to stdout but was", stdout.String())
+	}
+}
+
+// Incomplete tree calls write to the runtime stderr and exit with code 1
+func TestRuntime_incomplete(t *testing.T) {
+	rt, stdout, stderr, code := newTestRuntime()
+
+	_, err := cli.Tree().
+		WithRuntime(rt).
+		WithBranch(cli.Branch("branch").
+			WithLeaf(cli.Leaf("leaf"))).
+		Parse([]string{"my-app", "branch"})
+
+	var ee argo.ExitError
+	if !errors.As(err, &ee) || ee.Code() != 1 {
+		t.Fatal("expected err to be an ExitError with code 1 but was", err)
+	}
+
+	if *code != 1 {
+		t.Error("expected exit code to be 1 but was", *code)
+	}
+
+	if stdout.Len() > 0 {
+		t.Error("expected nothing to be written to stdout but was", stdout.String())
+	}
+
+	if !strings.HasPrefix(stderr.String(), "Usage:\n  my-app branch") {
+		t.Error("expected branch help text to be written to stderr but was", stderr.String())
+	}
+}
+
+// Environment variables are looked up through the runtime
+func TestRuntime_lookupEnv(t *testing.T) {
+	rt, _, _, _ := newTestRuntime()
+	var value string
+
+	_, err := cli.Command().
+		WithRuntime(rt).
+		WithFlag(cli.LongFlag("value").
+			WithBinding(&value, true).
+			WithEnvVar("MY_APP_VALUE")).
+		Parse([]string{"my-app"})
+
+	if err != nil {
+		t.Fatal("expected err to be nil but was", err)
+	}
+
+	if value != "from-env" {
+		t.Error("expected value to be from-env but was", value)
+	}
+}
+
+// Dynamic completion writes to the runtime stdout
+func TestRuntime_completion(t *testing.T) {
+	rt, stdout, _, code := newTestRuntime()
+
+	_, err := cli.Tree().
+		WithRuntime(rt).
+		WithLeaf(cli.Leaf("build")).
+		WithLeaf(cli.Leaf("bump")).
+		Parse([]string{"my-app", argo.CompletionCommandName, "bu"})
+
+	var ee argo.ExitError
+	if !errors.As(err, &ee) {
+		t.Error("expected err to be an ExitError but was", err)
+	}
+
+	if *code != 0 {
+		t.Error("expected exit code to be 0 but was", *code)
+	}
+
+	if stdout.String() != "build\nbump\n" {
+		t.Errorf("expected completions to be written to stdout but was %q", stdout.String())
+	}
+}
@@ -0,0 +1,176 @@
+package argo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StructTagName is the name of the struct tag read by CommandFromStruct and
+// CommandTreeFromStruct.
+const StructTagName = "argo"
+
+type structTagKind uint8
+
+const (
+	structTagKindUnknown structTagKind = iota
+	structTagKindFlag
+	structTagKindArgument
+	structTagKindFlagGroup
+	structTagKindLeaf
+	structTagKindBranch
+	structTagKindSkip
+)
+
+type structTag struct {
+	kind structTagKind
+
+	short byte
+	long  string
+	name  string
+	desc  string
+	env   string
+	def   string
+
+	aliases []string
+
+	hasDefault  bool
+	required    bool
+	argOptional bool
+}
+
+// parseStructTag parses the given raw struct tag value into a structTag.
+//
+// Struct tags consist of comma separated keys, some of which take values
+// attached with an equals character.  Commas and backslashes may be escaped
+// in values with a leading backslash.
+//
+// Example:
+//     `argo:"short=v,long=verbose,desc=Enable verbose logging\, very loud."`
+func parseStructTag(raw string) (structTag, error) {
+	var out structTag
+
+	if raw == "-" {
+		out.kind = structTagKindSkip
+		return out, nil
+	}
+
+	for _, segment := range splitStructTag(raw) {
+		var key, value string
+		var hasValue bool
+
+		if idx := strings.IndexByte(segment, '='); idx > -1 {
+			key, value, hasValue = strings.TrimSpace(segment[:idx]), segment[idx+1:], true
+		} else {
+			key = strings.TrimSpace(segment)
+		}
+
+		switch key {
+		case "":
+			continue
+
+		case "short":
+			if len(value) != 1 {
+				return out, fmt.Errorf("struct tag key \"short\" requires a single character value, got \"%s\"", value)
+			}
+			out.short = value[0]
+
+		case "long":
+			out.long = value
+
+		case "name":
+			out.name = value
+
+		case "desc":
+			out.desc = value
+
+		case "env":
+			out.env = value
+
+		case "default":
+			out.def = value
+			out.hasDefault = true
+
+		case "alias":
+			out.aliases = append(out.aliases, value)
+
+		case "required":
+			out.required = true
+
+		case "arg-optional":
+			out.argOptional = true
+
+		case "arg":
+			if err := out.setKind(structTagKindArgument); err != nil {
+				return out, err
+			}
+
+		case "group":
+			if err := out.setKind(structTagKindFlagGroup); err != nil {
+				return out, err
+			}
+
+		case "leaf":
+			if err := out.setKind(structTagKindLeaf); err != nil {
+				return out, err
+			}
+
+		case "branch":
+			if err := out.setKind(structTagKindBranch); err != nil {
+				return out, err
+			}
+
+		default:
+			return out, fmt.Errorf("unrecognized struct tag key \"%s\"", key)
+		}
+
+		if hasValue && !structTagKeyTakesValue(key) {
+			return out, fmt.Errorf("struct tag key \"%s\" does not take a value", key)
+		}
+	}
+
+	if out.kind == structTagKindUnknown && (out.short != 0 || len(out.long) > 0) {
+		out.kind = structTagKindFlag
+	}
+
+	return out, nil
+}
+
+func (s *structTag) setKind(kind structTagKind) error {
+	if s.kind != structTagKindUnknown && s.kind != kind {
+		return fmt.Errorf("struct tag declares more than one of \"arg\", \"group\", \"leaf\", or \"branch\"")
+	}
+
+	s.kind = kind
+	return nil
+}
+
+func structTagKeyTakesValue(key string) bool {
+	switch key {
+	case "short", "long", "name", "desc", "env", "default", "alias":
+		return true
+	default:
+		return false
+	}
+}
+
+func splitStructTag(raw string) []string {
+	out := make([]string, 0, 4)
+	sb := strings.Builder{}
+
+	for i := 0; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			if i+1 < len(raw) {
+				i++
+			}
+			sb.WriteByte(raw[i])
+		case ',':
+			out = append(out, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(raw[i])
+		}
+	}
+
+	return append(out, sb.String())
+}
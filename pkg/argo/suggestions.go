@@ -0,0 +1,113 @@
+package argo
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/internal/chars"
+	"github.com/Foxcapades/Argonaut/internal/util"
+)
+
+// DefaultSuggestionThreshold is the maximum edit distance between an
+// unrecognized subcommand or flag and a known one for the known one to be
+// suggested, when no other threshold has been configured.
+const DefaultSuggestionThreshold = 2
+
+// suggest returns the given candidates that are within the given edit distance
+// of the given input, closest first.
+//
+// Candidates are compared to the input case-insensitively.  A threshold less
+// than 1 disables suggestions.
+func suggest(input string, candidates []string, threshold int) []string {
+	if threshold < 1 {
+		return nil
+	}
+
+	type match struct {
+		distance  int
+		candidate string
+	}
+
+	lowered := strings.ToLower(input)
+	matches := make([]match, 0, 4)
+	seen := make(map[string]bool, len(candidates))
+
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+
+		seen[candidate] = true
+
+		if distance := util.EditDistance(lowered, strings.ToLower(candidate)); distance <= threshold {
+			matches = append(matches, match{distance, candidate})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+
+		return matches[i].candidate < matches[j].candidate
+	})
+
+	out := make([]string, len(matches))
+	for i := range matches {
+		out[i] = matches[i].candidate
+	}
+
+	return out
+}
+
+// suggestLongFlags returns the long forms of the visible flags in the given
+// groups that are similar to the given unrecognized long flag name, in their CLI
+// form, for example "--verbose".
+func suggestLongFlags(groups []FlagGroup, name string, threshold int) []string {
+	names := make([]string, 0, 8)
+
+	for _, group := range visibleFlagGroups(groups) {
+		for _, flag := range group.Flags() {
+			if flag.HasLongForm() {
+				names = append(names, flag.LongForm())
+
+				if flag.IsNegatable() {
+					names = append(names, negatedFlagPrefix+flag.LongForm())
+				}
+			}
+		}
+	}
+
+	out := suggest(name, names, threshold)
+	for i := range out {
+		out[i] = chars.StrDoubleDash + out[i]
+	}
+
+	return out
+}
+
+// suggestChildren returns the names and aliases of the visible subcommands of
+// the given parent that are similar to the given unrecognized subcommand name.
+func suggestChildren(parent CommandParent, name string, threshold int) []string {
+	names := make([]string, 0, 8)
+
+	for _, group := range visibleCommandGroups(parent.CommandGroups()) {
+		for _, child := range group.Branches() {
+			names = append(names, child.Name())
+			names = append(names, child.Aliases()...)
+		}
+
+		for _, child := range group.Leaves() {
+			names = append(names, child.Name())
+			names = append(names, child.Aliases()...)
+		}
+	}
+
+	return suggest(name, names, threshold)
+}
+
+// newUnknownLongFlagError returns an UnknownFlagError for the given unrecognized
+// long flag name, suggesting similar flags from the given flag groups.
+func newUnknownLongFlagError(groups []FlagGroup, name string, threshold int) UnknownFlagError {
+	return newUnknownFlagError(chars.StrDoubleDash+name, suggestLongFlags(groups, name, threshold))
+}
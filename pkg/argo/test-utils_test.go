@@ -1,6 +1,10 @@
 package argo_test
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
 
 type FailingWriter struct {
 	FailAfter int
@@ -15,3 +19,27 @@ func (f *FailingWriter) Write(p []byte) (n int, err error) {
 		return 0, errors.New("fake error")
 	}
 }
+
+// renderTestTree returns the command tree shared by the tree renderer, doc
+// exporter, and completion tests.
+func renderTestTree() argo.CommandTree {
+	return argo.NewCommandTreeBuilder().
+		WithDescription("A tree.").
+		WithFlag(argo.NewFlagBuilder().WithShortForm('v').WithLongForm("verbose").WithDescription("Be loud.")).
+		WithFlag(argo.NewFlagBuilder().
+			WithLongForm("config").
+			WithDescription("Config file.").
+			WithArgument(argo.NewArgumentBuilder().WithName("path").Require())).
+		WithBranch(argo.NewCommandBranchBuilder("files").
+			WithDescription("File operations.").
+			WithLeaf(argo.NewCommandLeafBuilder("remove").
+				WithAliases("rm").
+				WithDescription("Removes files.").
+				WithFlag(argo.NewFlagBuilder().
+					WithShortForm('o').
+					WithLongForm("output").
+					WithArgument(argo.NewArgumentBuilder().WithName("dir").WithEnvVar("APP_DIR").Require())).
+				WithArgument(argo.NewArgumentBuilder().WithName("file").WithDescription("File to remove.").Require()))).
+		WithLeaf(argo.NewCommandLeafBuilder("build").WithDescription("Builds things.")).
+		MustParse([]string{"command", "build"})
+}
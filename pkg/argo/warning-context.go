@@ -2,12 +2,29 @@ package argo
 
 type WarningContext struct {
 	warnings []string
+	errors   []error
 }
 
 func (w *WarningContext) appendWarning(warning string) {
 	w.warnings = append(w.warnings, warning)
 }
 
+// appendWarningError appends the message of the given error as a warning, and
+// retains the error itself so callers may inspect its details.
+func (w *WarningContext) appendWarningError(err error) {
+	w.warnings = append(w.warnings, err.Error())
+	w.errors = append(w.errors, err)
+}
+
 func (w WarningContext) GetWarnings() []string {
 	return w.warnings
 }
+
+// GetWarningErrors returns the warnings that carry additional details, such as
+// an UnknownFlagError, as error values.
+//
+// The message of each of these errors is also included in the values returned
+// by GetWarnings.
+func (w WarningContext) GetWarningErrors() []error {
+	return w.errors
+}
@@ -0,0 +1,317 @@
+// Package argotest provides an in-process harness for testing command line
+// interfaces built with the argo package.
+//
+// The harness runs a CommandBuilder or CommandTreeBuilder against a slice of
+// CLI arguments without touching the real process environment.  Output that
+// would be written to stdout or stderr is captured, and calls that would exit
+// the process, such as `--help`, are recorded instead.
+//
+// Running a builder replaces its runtime with the harness runtime, so a builder
+// passed to the harness should not be built again outside of it.
+//
+// Example:
+//     func TestHelp(t *testing.T) {
+//         res := argotest.RunTree(newCLI(), "serve", "--help")
+//
+//         if res.ExitCode != 0 {
+//             t.Fatal("expected exit code 0 but was", res.ExitCode)
+//         }
+//
+//         argotest.AssertGolden(t, "testdata/serve-help.golden", res.Stdout)
+//     }
+package argotest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+)
+
+// DefaultProgramName is the program name used by RunCommand and RunTree, and by
+// Runner instances that do not have a program name set.
+const DefaultProgramName = "app"
+
+// A Runner runs commands in a controlled environment.
+//
+// The zero value is ready to use, and runs commands with the program name
+// DefaultProgramName, an empty environment, and empty stdin.
+type Runner struct {
+	// ProgramName is the program name that will be used as the first CLI
+	// argument and in rendered help text.
+	ProgramName string
+
+	// Env is the set of environment variables that will be visible to the
+	// command being run.  The real process environment is never consulted.
+	Env map[string]string
+
+	// Stdin is the input that will be made available to the command being run.
+	Stdin io.Reader
+}
+
+// RunCommand builds the given CommandBuilder and parses the given arguments
+// using a zero value Runner.
+//
+// The given arguments should not include the program name.  As with
+// Runner.RunCommand, the given builder is consumed.
+func RunCommand(builder argo.CommandBuilder, args ...string) *Result {
+	return Runner{}.RunCommand(builder, args...)
+}
+
+// RunTree builds the given CommandTreeBuilder and parses the given arguments
+// using a zero value Runner.
+//
+// The given arguments should not include the program name.  As with
+// Runner.RunTree, the given builder is consumed.
+func RunTree(builder argo.CommandTreeBuilder, args ...string) *Result {
+	return Runner{}.RunTree(builder, args...)
+}
+
+// RunCommand builds the given CommandBuilder and parses the given arguments.
+//
+// The given arguments should not include the program name.
+//
+// The given builder is consumed: its runtime is replaced with one that captures
+// output and exit requests, and is not restored afterward.
+func (r Runner) RunCommand(builder argo.CommandBuilder, args ...string) *Result {
+	res, rt := r.newResult()
+	ctx := new(argo.WarningContext)
+
+	com, err := builder.WithRuntime(rt).Build(ctx)
+	if err != nil {
+		res.BuildErr = err
+		res.ExitCode = 1
+		return res
+	}
+
+	res.Command = com
+	res.complete(ctx, argo.ParseCommand(com, r.argv(args)))
+
+	return res
+}
+
+// RunTree builds the given CommandTreeBuilder and parses the given arguments.
+//
+// The given arguments should not include the program name.
+//
+// The given builder is consumed: its runtime is replaced with one that captures
+// output and exit requests, and is not restored afterward.
+func (r Runner) RunTree(builder argo.CommandTreeBuilder, args ...string) *Result {
+	res, rt := r.newResult()
+	ctx := new(argo.WarningContext)
+
+	tree, err := builder.WithRuntime(rt).Build(ctx)
+	if err != nil {
+		res.BuildErr = err
+		res.ExitCode = 1
+		return res
+	}
+
+	res.Tree = tree
+	res.complete(ctx, argo.ParseCommandTree(tree, r.argv(args)))
+	res.Leaf = tree.SelectedCommand()
+
+	return res
+}
+
+func (r Runner) programName() string {
+	if len(r.ProgramName) == 0 {
+		return DefaultProgramName
+	}
+
+	return r.ProgramName
+}
+
+func (r Runner) argv(args []string) []string {
+	return append([]string{r.programName()}, args...)
+}
+
+func (r Runner) newResult() (*Result, *argo.Runtime) {
+	res := &Result{ExitCode: -1}
+
+	stdin := r.Stdin
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+
+	rt := &argo.Runtime{
+		Stdout: &res.stdout,
+		Stderr: &res.stderr,
+		Stdin:  stdin,
+		Exit: func(code int) {
+			if !res.Exited {
+				res.Exited = true
+				res.ExitCode = code
+			}
+		},
+		ProgramName: r.programName(),
+		LookupEnv: func(key string) (string, bool) {
+			value, ok := r.Env[key]
+			return value, ok
+		},
+	}
+
+	return res, rt
+}
+
+// Result holds the outcome of running a command with a Runner.
+type Result struct {
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+
+	// Stdout is everything the command wrote to stdout.
+	Stdout string
+
+	// Stderr is everything the command wrote to stderr.
+	Stderr string
+
+	// ExitCode is the exit code the command exited with.
+	//
+	// If the command requested an exit, for example after printing help text,
+	// this is the code it requested.  Otherwise, this is 1 if building or
+	// parsing the command failed, and 0 if it succeeded.
+	ExitCode int
+
+	// Exited indicates whether the command requested that the process exit.
+	Exited bool
+
+	// BuildErr is the error returned when building the command, if any.
+	BuildErr error
+
+	// Err is the error returned when parsing the CLI arguments, if any.
+	//
+	// Exit requests are not considered errors, and are reported by Exited and
+	// ExitCode instead.
+	Err error
+
+	// Warnings are the warnings collected while building and parsing the
+	// command.
+	Warnings []string
+
+	// Command is the built command when run with RunCommand.
+	Command argo.Command
+
+	// Tree is the built command tree when run with RunTree.
+	Tree argo.CommandTree
+
+	// Leaf is the leaf command that was selected when run with RunTree.
+	//
+	// This will be set even if parsing failed, as long as a leaf command was
+	// reached.
+	Leaf argo.CommandLeaf
+}
+
+func (r *Result) complete(ctx *argo.WarningContext, err error) {
+	var exit argo.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		r.Err = err
+	}
+
+	if !r.Exited {
+		if r.Err != nil {
+			r.ExitCode = 1
+		} else {
+			r.ExitCode = 0
+		}
+	}
+
+	r.Stdout = r.stdout.String()
+	r.Stderr = r.stderr.String()
+	r.Warnings = ctx.GetWarnings()
+}
+
+// Errors returns the individual errors that occurred while building or parsing
+// the command.
+func (r *Result) Errors() []error {
+	err := r.BuildErr
+	if err == nil {
+		err = r.Err
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	var multi argo.MultiError
+	if errors.As(err, &multi) {
+		return multi.Errors()
+	}
+
+	return []error{err}
+}
+
+// HasErrors indicates whether any errors occurred while building or parsing the
+// command.
+func (r *Result) HasErrors() bool {
+	return r.BuildErr != nil || r.Err != nil
+}
+
+// FindLongFlag looks up a flag by its long form on the command that was run,
+// or for command trees, on the selected leaf and its parents.
+//
+// If no such flag could be found, this method returns nil.
+func (r *Result) FindLongFlag(name string) argo.Flag {
+	if r.Command != nil {
+		return r.Command.FindLongFlag(name)
+	}
+
+	if r.Leaf != nil {
+		return r.Leaf.FindLongFlag(name)
+	}
+
+	if r.Tree != nil {
+		return r.Tree.FindLongFlag(name)
+	}
+
+	return nil
+}
+
+// FindShortFlag looks up a flag by its short form on the command that was run,
+// or for command trees, on the selected leaf and its parents.
+//
+// If no such flag could be found, this method returns nil.
+func (r *Result) FindShortFlag(c byte) argo.Flag {
+	if r.Command != nil {
+		return r.Command.FindShortFlag(c)
+	}
+
+	if r.Leaf != nil {
+		return r.Leaf.FindShortFlag(c)
+	}
+
+	if r.Tree != nil {
+		return r.Tree.FindShortFlag(c)
+	}
+
+	return nil
+}
+
+// Arguments returns the positional arguments of the command that was run, or
+// of the selected leaf for command trees.
+func (r *Result) Arguments() []argo.Argument {
+	if r.Command != nil {
+		return r.Command.Arguments()
+	}
+
+	if r.Leaf != nil {
+		return r.Leaf.Arguments()
+	}
+
+	return nil
+}
+
+// UnmappedInputs returns the unmapped inputs collected by the command that was
+// run, or by the selected leaf for command trees.
+func (r *Result) UnmappedInputs() []string {
+	if r.Command != nil {
+		return r.Command.UnmappedInputs()
+	}
+
+	if r.Leaf != nil {
+		return r.Leaf.UnmappedInputs()
+	}
+
+	return nil
+}
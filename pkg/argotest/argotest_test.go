@@ -0,0 +1,174 @@
+package argotest_test
+
+import (
+	"testing"
+
+	cli "github.com/Foxcapades/Argonaut"
+	"github.com/Foxcapades/Argonaut/pkg/argo"
+	"github.com/Foxcapades/Argonaut/pkg/argotest"
+)
+
+func newTestCommand(name *string, count *int) argo.CommandBuilder {
+	return cli.Command().
+		WithDescription("Greets people.").
+		WithFlag(cli.LongFlag("name").
+			WithDescription("Name to greet.").
+			WithBinding(name, true).
+			WithEnvVar("GREET_NAME").
+			Require()).
+		WithArgument(cli.Argument().
+			WithName("count").
+			WithBinding(count))
+}
+
+func newTestTree(name *string) argo.CommandTreeBuilder {
+	return cli.Tree().
+		WithDescription("Example tree.").
+		WithLeaf(cli.Leaf("greet").
+			WithDescription("Greets people.").
+			WithFlag(cli.LongFlag("name").
+				WithDescription("Name to greet.").
+				WithBinding(name, true)))
+}
+
+func TestRunCommand(t *testing.T) {
+	var name string
+	var count int
+
+	res := argotest.RunCommand(newTestCommand(&name, &count), "--name=Alice", "3")
+
+	if res.HasErrors() {
+		t.Fatal("expected no errors but got", res.Errors())
+	}
+
+	if res.ExitCode != 0 || res.Exited {
+		t.Error("expected exit code 0 without exiting but was", res.ExitCode, res.Exited)
+	}
+
+	if name != "Alice" {
+		t.Error("expected name to be Alice but was", name)
+	}
+
+	if count != 3 {
+		t.Error("expected count to be 3 but was", count)
+	}
+
+	if flag := res.FindLongFlag("name"); flag == nil || !flag.WasHit() {
+		t.Error("expected --name flag to have been hit")
+	}
+
+	if len(res.Arguments()) != 1 || !res.Arguments()[0].WasHit() {
+		t.Error("expected count argument to have been hit")
+	}
+}
+
+func TestRunCommandError(t *testing.T) {
+	var name string
+	var count int
+
+	res := argotest.RunCommand(newTestCommand(&name, &count))
+
+	if res.ExitCode != 1 {
+		t.Error("expected exit code 1 but was", res.ExitCode)
+	}
+
+	if len(res.Errors()) != 1 {
+		t.Error("expected 1 error but got", res.Errors())
+	}
+}
+
+func TestRunCommandHelp(t *testing.T) {
+	var name string
+	var count int
+
+	res := argotest.RunCommand(newTestCommand(&name, &count), "--help")
+
+	if !res.Exited || res.ExitCode != 0 {
+		t.Error("expected exit code 0 but was", res.ExitCode)
+	}
+
+	if res.HasErrors() {
+		t.Error("expected no errors but got", res.Errors())
+	}
+
+	argotest.AssertGolden(t, "testdata/command-help.golden", res.Stdout)
+}
+
+func TestRunnerEnv(t *testing.T) {
+	var name string
+	var count int
+
+	runner := argotest.Runner{Env: map[string]string{"GREET_NAME": "Bob"}}
+	res := runner.RunCommand(newTestCommand(&name, &count))
+
+	if res.HasErrors() {
+		t.Fatal("expected no errors but got", res.Errors())
+	}
+
+	if name != "Bob" {
+		t.Error("expected name to be Bob but was", name)
+	}
+}
+
+func TestRunnerProgramName(t *testing.T) {
+	var name string
+
+	runner := argotest.Runner{ProgramName: "greeter"}
+	res := runner.RunTree(newTestTree(&name), "--help")
+
+	if res.Tree.Name() != "greeter" {
+		t.Error("expected tree name to be greeter but was", res.Tree.Name())
+	}
+
+	argotest.AssertGolden(t, "testdata/tree-help.golden", res.Stdout)
+}
+
+func TestRunTree(t *testing.T) {
+	var name string
+
+	res := argotest.RunTree(newTestTree(&name), "greet", "--name", "Carol", "extra")
+
+	if res.HasErrors() {
+		t.Fatal("expected no errors but got", res.Errors())
+	}
+
+	if res.Leaf == nil || res.Leaf.Name() != "greet" {
+		t.Fatal("expected greet leaf to be selected but was", res.Leaf)
+	}
+
+	if name != "Carol" {
+		t.Error("expected name to be Carol but was", name)
+	}
+
+	if len(res.UnmappedInputs()) != 1 || res.UnmappedInputs()[0] != "extra" {
+		t.Error("expected unmapped inputs to be [extra] but was", res.UnmappedInputs())
+	}
+}
+
+func TestRunTreeIncomplete(t *testing.T) {
+	var name string
+
+	res := argotest.RunTree(newTestTree(&name))
+
+	if !res.Exited || res.ExitCode != 1 {
+		t.Error("expected exit code 1 but was", res.ExitCode)
+	}
+
+	if len(res.Stderr) == 0 {
+		t.Error("expected help text to be written to stderr")
+	}
+}
+
+func TestRunBuildError(t *testing.T) {
+	res := argotest.RunCommand(cli.Command().
+		WithFlag(cli.LongFlag("foo")).
+		WithFlag(cli.LongFlag("foo")))
+
+	if res.BuildErr == nil {
+		t.Error("expected a build error")
+	}
+
+	if res.ExitCode != 1 {
+		t.Error("expected exit code 1 but was", res.ExitCode)
+	}
+}
@@ -0,0 +1,45 @@
+package argotest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("argotest.update", false, "update argotest golden files")
+
+// AssertGolden compares the given value against the contents of the golden
+// file at the given path, failing the test if they differ.
+//
+// When tests are run with the `-argotest.update` flag, or with the
+// ARGOTEST_UPDATE environment variable set, the golden file is instead
+// overwritten with the given value.
+//
+// Example:
+//     res := argotest.RunCommand(newCLI(), "--help")
+//     argotest.AssertGolden(t, "testdata/help.golden", res.Stdout)
+func AssertGolden(t testing.TB, path, actual string) {
+	t.Helper()
+
+	if *update || len(os.Getenv("ARGOTEST_UPDATE")) > 0 {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal("failed to create golden file directory:", err)
+		}
+
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal("failed to write golden file:", err)
+		}
+
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("failed to read golden file:", err)
+	}
+
+	if string(expected) != actual {
+		t.Errorf("output did not match golden file %s\n\nexpected:\n%s\n\ngot:\n%s", path, expected, actual)
+	}
+}